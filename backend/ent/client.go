@@ -16,6 +16,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/Wei-Shaw/sub2api/ent/account"
+	"github.com/Wei-Shaw/sub2api/ent/accountfingerprint"
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
 	"github.com/Wei-Shaw/sub2api/ent/group"
@@ -41,6 +42,8 @@ type Client struct {
 	APIKey *APIKeyClient
 	// Account is the client for interacting with the Account builders.
 	Account *AccountClient
+	// AccountFingerprint is the client for interacting with the AccountFingerprint builders.
+	AccountFingerprint *AccountFingerprintClient
 	// AccountGroup is the client for interacting with the AccountGroup builders.
 	AccountGroup *AccountGroupClient
 	// Group is the client for interacting with the Group builders.
@@ -76,6 +79,7 @@ func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.APIKey = NewAPIKeyClient(c.config)
 	c.Account = NewAccountClient(c.config)
+	c.AccountFingerprint = NewAccountFingerprintClient(c.config)
 	c.AccountGroup = NewAccountGroupClient(c.config)
 	c.Group = NewGroupClient(c.config)
 	c.Proxy = NewProxyClient(c.config)
@@ -181,6 +185,7 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		config:                  cfg,
 		APIKey:                  NewAPIKeyClient(cfg),
 		Account:                 NewAccountClient(cfg),
+		AccountFingerprint:      NewAccountFingerprintClient(cfg),
 		AccountGroup:            NewAccountGroupClient(cfg),
 		Group:                   NewGroupClient(cfg),
 		Proxy:                   NewProxyClient(cfg),
@@ -213,6 +218,7 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		config:                  cfg,
 		APIKey:                  NewAPIKeyClient(cfg),
 		Account:                 NewAccountClient(cfg),
+		AccountFingerprint:      NewAccountFingerprintClient(cfg),
 		AccountGroup:            NewAccountGroupClient(cfg),
 		Group:                   NewGroupClient(cfg),
 		Proxy:                   NewProxyClient(cfg),
@@ -253,9 +259,9 @@ func (c *Client) Close() error {
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
 	for _, n := range []interface{ Use(...Hook) }{
-		c.APIKey, c.Account, c.AccountGroup, c.Group, c.Proxy, c.RedeemCode, c.Setting,
-		c.UsageLog, c.User, c.UserAllowedGroup, c.UserAttributeDefinition,
-		c.UserAttributeValue, c.UserSubscription,
+		c.APIKey, c.Account, c.AccountFingerprint, c.AccountGroup, c.Group, c.Proxy,
+		c.RedeemCode, c.Setting, c.UsageLog, c.User, c.UserAllowedGroup,
+		c.UserAttributeDefinition, c.UserAttributeValue, c.UserSubscription,
 	} {
 		n.Use(hooks...)
 	}
@@ -265,9 +271,9 @@ func (c *Client) Use(hooks ...Hook) {
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	for _, n := range []interface{ Intercept(...Interceptor) }{
-		c.APIKey, c.Account, c.AccountGroup, c.Group, c.Proxy, c.RedeemCode, c.Setting,
-		c.UsageLog, c.User, c.UserAllowedGroup, c.UserAttributeDefinition,
-		c.UserAttributeValue, c.UserSubscription,
+		c.APIKey, c.Account, c.AccountFingerprint, c.AccountGroup, c.Group, c.Proxy,
+		c.RedeemCode, c.Setting, c.UsageLog, c.User, c.UserAllowedGroup,
+		c.UserAttributeDefinition, c.UserAttributeValue, c.UserSubscription,
 	} {
 		n.Intercept(interceptors...)
 	}
@@ -280,6 +286,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.APIKey.mutate(ctx, m)
 	case *AccountMutation:
 		return c.Account.mutate(ctx, m)
+	case *AccountFingerprintMutation:
+		return c.AccountFingerprint.mutate(ctx, m)
 	case *AccountGroupMutation:
 		return c.AccountGroup.mutate(ctx, m)
 	case *GroupMutation:
@@ -689,6 +697,139 @@ func (c *AccountClient) mutate(ctx context.Context, m *AccountMutation) (Value,
 	}
 }
 
+// AccountFingerprintClient is a client for the AccountFingerprint schema.
+type AccountFingerprintClient struct {
+	config
+}
+
+// NewAccountFingerprintClient returns a client for the AccountFingerprint from the given config.
+func NewAccountFingerprintClient(c config) *AccountFingerprintClient {
+	return &AccountFingerprintClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `accountfingerprint.Hooks(f(g(h())))`.
+func (c *AccountFingerprintClient) Use(hooks ...Hook) {
+	c.hooks.AccountFingerprint = append(c.hooks.AccountFingerprint, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `accountfingerprint.Intercept(f(g(h())))`.
+func (c *AccountFingerprintClient) Intercept(interceptors ...Interceptor) {
+	c.inters.AccountFingerprint = append(c.inters.AccountFingerprint, interceptors...)
+}
+
+// Create returns a builder for creating a AccountFingerprint entity.
+func (c *AccountFingerprintClient) Create() *AccountFingerprintCreate {
+	mutation := newAccountFingerprintMutation(c.config, OpCreate)
+	return &AccountFingerprintCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of AccountFingerprint entities.
+func (c *AccountFingerprintClient) CreateBulk(builders ...*AccountFingerprintCreate) *AccountFingerprintCreateBulk {
+	return &AccountFingerprintCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *AccountFingerprintClient) MapCreateBulk(slice any, setFunc func(*AccountFingerprintCreate, int)) *AccountFingerprintCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &AccountFingerprintCreateBulk{err: fmt.Errorf("calling to AccountFingerprintClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*AccountFingerprintCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &AccountFingerprintCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for AccountFingerprint.
+func (c *AccountFingerprintClient) Update() *AccountFingerprintUpdate {
+	mutation := newAccountFingerprintMutation(c.config, OpUpdate)
+	return &AccountFingerprintUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *AccountFingerprintClient) UpdateOne(_m *AccountFingerprint) *AccountFingerprintUpdateOne {
+	mutation := newAccountFingerprintMutation(c.config, OpUpdateOne, withAccountFingerprint(_m))
+	return &AccountFingerprintUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *AccountFingerprintClient) UpdateOneID(id int64) *AccountFingerprintUpdateOne {
+	mutation := newAccountFingerprintMutation(c.config, OpUpdateOne, withAccountFingerprintID(id))
+	return &AccountFingerprintUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for AccountFingerprint.
+func (c *AccountFingerprintClient) Delete() *AccountFingerprintDelete {
+	mutation := newAccountFingerprintMutation(c.config, OpDelete)
+	return &AccountFingerprintDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *AccountFingerprintClient) DeleteOne(_m *AccountFingerprint) *AccountFingerprintDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *AccountFingerprintClient) DeleteOneID(id int64) *AccountFingerprintDeleteOne {
+	builder := c.Delete().Where(accountfingerprint.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &AccountFingerprintDeleteOne{builder}
+}
+
+// Query returns a query builder for AccountFingerprint.
+func (c *AccountFingerprintClient) Query() *AccountFingerprintQuery {
+	return &AccountFingerprintQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeAccountFingerprint},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a AccountFingerprint entity by its id.
+func (c *AccountFingerprintClient) Get(ctx context.Context, id int64) (*AccountFingerprint, error) {
+	return c.Query().Where(accountfingerprint.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *AccountFingerprintClient) GetX(ctx context.Context, id int64) *AccountFingerprint {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *AccountFingerprintClient) Hooks() []Hook {
+	return c.hooks.AccountFingerprint
+}
+
+// Interceptors returns the client interceptors.
+func (c *AccountFingerprintClient) Interceptors() []Interceptor {
+	return c.inters.AccountFingerprint
+}
+
+func (c *AccountFingerprintClient) mutate(ctx context.Context, m *AccountFingerprintMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&AccountFingerprintCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&AccountFingerprintUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&AccountFingerprintUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&AccountFingerprintDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown AccountFingerprint mutation op: %q", m.Op())
+	}
+}
+
 // AccountGroupClient is a client for the AccountGroup schema.
 type AccountGroupClient struct {
 	config
@@ -2627,14 +2768,14 @@ func (c *UserSubscriptionClient) mutate(ctx context.Context, m *UserSubscription
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		APIKey, Account, AccountGroup, Group, Proxy, RedeemCode, Setting, UsageLog,
-		User, UserAllowedGroup, UserAttributeDefinition, UserAttributeValue,
-		UserSubscription []ent.Hook
+		APIKey, Account, AccountFingerprint, AccountGroup, Group, Proxy, RedeemCode,
+		Setting, UsageLog, User, UserAllowedGroup, UserAttributeDefinition,
+		UserAttributeValue, UserSubscription []ent.Hook
 	}
 	inters struct {
-		APIKey, Account, AccountGroup, Group, Proxy, RedeemCode, Setting, UsageLog,
-		User, UserAllowedGroup, UserAttributeDefinition, UserAttributeValue,
-		UserSubscription []ent.Interceptor
+		APIKey, Account, AccountFingerprint, AccountGroup, Group, Proxy, RedeemCode,
+		Setting, UsageLog, User, UserAllowedGroup, UserAttributeDefinition,
+		UserAttributeValue, UserSubscription []ent.Interceptor
 	}
 )
 
@@ -160,6 +160,28 @@ var (
 			},
 		},
 	}
+	// AccountFingerprintsColumns holds the columns for the "account_fingerprints" table.
+	AccountFingerprintsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "account_id", Type: field.TypeInt64, Unique: true},
+		{Name: "schema_version", Type: field.TypeInt, Default: 0},
+		{Name: "client_id", Type: field.TypeString, Nullable: true},
+		{Name: "user_agent", Type: field.TypeString, Nullable: true},
+		{Name: "stainless_lang", Type: field.TypeString, Nullable: true},
+		{Name: "stainless_package_version", Type: field.TypeString, Nullable: true},
+		{Name: "stainless_os", Type: field.TypeString, Nullable: true},
+		{Name: "stainless_arch", Type: field.TypeString, Nullable: true},
+		{Name: "stainless_runtime", Type: field.TypeString, Nullable: true},
+		{Name: "stainless_runtime_version", Type: field.TypeString, Nullable: true},
+		{Name: "fingerprint_created_at", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"postgres": "timestamptz"}},
+		{Name: "updated_at", Type: field.TypeTime, SchemaType: map[string]string{"postgres": "timestamptz"}},
+	}
+	// AccountFingerprintsTable holds the schema information for the "account_fingerprints" table.
+	AccountFingerprintsTable = &schema.Table{
+		Name:       "account_fingerprints",
+		Columns:    AccountFingerprintsColumns,
+		PrimaryKey: []*schema.Column{AccountFingerprintsColumns[0]},
+	}
 	// AccountGroupsColumns holds the columns for the "account_groups" table.
 	AccountGroupsColumns = []*schema.Column{
 		{Name: "priority", Type: field.TypeInt, Default: 50},
@@ -710,6 +732,7 @@ var (
 	Tables = []*schema.Table{
 		APIKeysTable,
 		AccountsTable,
+		AccountFingerprintsTable,
 		AccountGroupsTable,
 		GroupsTable,
 		ProxiesTable,
@@ -734,6 +757,9 @@ func init() {
 	AccountsTable.Annotation = &entsql.Annotation{
 		Table: "accounts",
 	}
+	AccountFingerprintsTable.Annotation = &entsql.Annotation{
+		Table: "account_fingerprints",
+	}
 	AccountGroupsTable.ForeignKeys[0].RefTable = AccountsTable
 	AccountGroupsTable.ForeignKeys[1].RefTable = GroupsTable
 	AccountGroupsTable.Annotation = &entsql.Annotation{
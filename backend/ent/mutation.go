@@ -12,6 +12,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/Wei-Shaw/sub2api/ent/account"
+	"github.com/Wei-Shaw/sub2api/ent/accountfingerprint"
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
 	"github.com/Wei-Shaw/sub2api/ent/group"
@@ -38,6 +39,7 @@ const (
 	// Node types.
 	TypeAPIKey                  = "APIKey"
 	TypeAccount                 = "Account"
+	TypeAccountFingerprint      = "AccountFingerprint"
 	TypeAccountGroup            = "AccountGroup"
 	TypeGroup                   = "Group"
 	TypeProxy                   = "Proxy"
@@ -2947,6 +2949,1169 @@ func (m *AccountMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown Account edge %s", name)
 }
 
+// AccountFingerprintMutation represents an operation that mutates the AccountFingerprint nodes in the graph.
+type AccountFingerprintMutation struct {
+	config
+	op                        Op
+	typ                       string
+	id                        *int64
+	account_id                *int64
+	addaccount_id             *int64
+	schema_version            *int
+	addschema_version         *int
+	client_id                 *string
+	user_agent                *string
+	stainless_lang            *string
+	stainless_package_version *string
+	stainless_os              *string
+	stainless_arch            *string
+	stainless_runtime         *string
+	stainless_runtime_version *string
+	fingerprint_created_at    *time.Time
+	updated_at                *time.Time
+	clearedFields             map[string]struct{}
+	done                      bool
+	oldValue                  func(context.Context) (*AccountFingerprint, error)
+	predicates                []predicate.AccountFingerprint
+}
+
+var _ ent.Mutation = (*AccountFingerprintMutation)(nil)
+
+// accountfingerprintOption allows management of the mutation configuration using functional options.
+type accountfingerprintOption func(*AccountFingerprintMutation)
+
+// newAccountFingerprintMutation creates new mutation for the AccountFingerprint entity.
+func newAccountFingerprintMutation(c config, op Op, opts ...accountfingerprintOption) *AccountFingerprintMutation {
+	m := &AccountFingerprintMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeAccountFingerprint,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withAccountFingerprintID sets the ID field of the mutation.
+func withAccountFingerprintID(id int64) accountfingerprintOption {
+	return func(m *AccountFingerprintMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *AccountFingerprint
+		)
+		m.oldValue = func(ctx context.Context) (*AccountFingerprint, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().AccountFingerprint.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withAccountFingerprint sets the old AccountFingerprint of the mutation.
+func withAccountFingerprint(node *AccountFingerprint) accountfingerprintOption {
+	return func(m *AccountFingerprintMutation) {
+		m.oldValue = func(context.Context) (*AccountFingerprint, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m AccountFingerprintMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m AccountFingerprintMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *AccountFingerprintMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *AccountFingerprintMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().AccountFingerprint.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetAccountID sets the "account_id" field.
+func (m *AccountFingerprintMutation) SetAccountID(i int64) {
+	m.account_id = &i
+	m.addaccount_id = nil
+}
+
+// AccountID returns the value of the "account_id" field in the mutation.
+func (m *AccountFingerprintMutation) AccountID() (r int64, exists bool) {
+	v := m.account_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAccountID returns the old "account_id" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldAccountID(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAccountID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAccountID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAccountID: %w", err)
+	}
+	return oldValue.AccountID, nil
+}
+
+// AddAccountID adds i to the "account_id" field.
+func (m *AccountFingerprintMutation) AddAccountID(i int64) {
+	if m.addaccount_id != nil {
+		*m.addaccount_id += i
+	} else {
+		m.addaccount_id = &i
+	}
+}
+
+// AddedAccountID returns the value that was added to the "account_id" field in this mutation.
+func (m *AccountFingerprintMutation) AddedAccountID() (r int64, exists bool) {
+	v := m.addaccount_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetAccountID resets all changes to the "account_id" field.
+func (m *AccountFingerprintMutation) ResetAccountID() {
+	m.account_id = nil
+	m.addaccount_id = nil
+}
+
+// SetSchemaVersion sets the "schema_version" field.
+func (m *AccountFingerprintMutation) SetSchemaVersion(i int) {
+	m.schema_version = &i
+	m.addschema_version = nil
+}
+
+// SchemaVersion returns the value of the "schema_version" field in the mutation.
+func (m *AccountFingerprintMutation) SchemaVersion() (r int, exists bool) {
+	v := m.schema_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSchemaVersion returns the old "schema_version" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldSchemaVersion(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSchemaVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSchemaVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSchemaVersion: %w", err)
+	}
+	return oldValue.SchemaVersion, nil
+}
+
+// AddSchemaVersion adds i to the "schema_version" field.
+func (m *AccountFingerprintMutation) AddSchemaVersion(i int) {
+	if m.addschema_version != nil {
+		*m.addschema_version += i
+	} else {
+		m.addschema_version = &i
+	}
+}
+
+// AddedSchemaVersion returns the value that was added to the "schema_version" field in this mutation.
+func (m *AccountFingerprintMutation) AddedSchemaVersion() (r int, exists bool) {
+	v := m.addschema_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetSchemaVersion resets all changes to the "schema_version" field.
+func (m *AccountFingerprintMutation) ResetSchemaVersion() {
+	m.schema_version = nil
+	m.addschema_version = nil
+}
+
+// SetClientID sets the "client_id" field.
+func (m *AccountFingerprintMutation) SetClientID(s string) {
+	m.client_id = &s
+}
+
+// ClientID returns the value of the "client_id" field in the mutation.
+func (m *AccountFingerprintMutation) ClientID() (r string, exists bool) {
+	v := m.client_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClientID returns the old "client_id" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldClientID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClientID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
+	}
+	return oldValue.ClientID, nil
+}
+
+// ClearClientID clears the value of the "client_id" field.
+func (m *AccountFingerprintMutation) ClearClientID() {
+	m.client_id = nil
+	m.clearedFields[accountfingerprint.FieldClientID] = struct{}{}
+}
+
+// ClientIDCleared returns if the "client_id" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) ClientIDCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldClientID]
+	return ok
+}
+
+// ResetClientID resets all changes to the "client_id" field.
+func (m *AccountFingerprintMutation) ResetClientID() {
+	m.client_id = nil
+	delete(m.clearedFields, accountfingerprint.FieldClientID)
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (m *AccountFingerprintMutation) SetUserAgent(s string) {
+	m.user_agent = &s
+}
+
+// UserAgent returns the value of the "user_agent" field in the mutation.
+func (m *AccountFingerprintMutation) UserAgent() (r string, exists bool) {
+	v := m.user_agent
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserAgent returns the old "user_agent" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldUserAgent(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserAgent is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserAgent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserAgent: %w", err)
+	}
+	return oldValue.UserAgent, nil
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (m *AccountFingerprintMutation) ClearUserAgent() {
+	m.user_agent = nil
+	m.clearedFields[accountfingerprint.FieldUserAgent] = struct{}{}
+}
+
+// UserAgentCleared returns if the "user_agent" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) UserAgentCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldUserAgent]
+	return ok
+}
+
+// ResetUserAgent resets all changes to the "user_agent" field.
+func (m *AccountFingerprintMutation) ResetUserAgent() {
+	m.user_agent = nil
+	delete(m.clearedFields, accountfingerprint.FieldUserAgent)
+}
+
+// SetStainlessLang sets the "stainless_lang" field.
+func (m *AccountFingerprintMutation) SetStainlessLang(s string) {
+	m.stainless_lang = &s
+}
+
+// StainlessLang returns the value of the "stainless_lang" field in the mutation.
+func (m *AccountFingerprintMutation) StainlessLang() (r string, exists bool) {
+	v := m.stainless_lang
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStainlessLang returns the old "stainless_lang" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldStainlessLang(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStainlessLang is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStainlessLang requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStainlessLang: %w", err)
+	}
+	return oldValue.StainlessLang, nil
+}
+
+// ClearStainlessLang clears the value of the "stainless_lang" field.
+func (m *AccountFingerprintMutation) ClearStainlessLang() {
+	m.stainless_lang = nil
+	m.clearedFields[accountfingerprint.FieldStainlessLang] = struct{}{}
+}
+
+// StainlessLangCleared returns if the "stainless_lang" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) StainlessLangCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldStainlessLang]
+	return ok
+}
+
+// ResetStainlessLang resets all changes to the "stainless_lang" field.
+func (m *AccountFingerprintMutation) ResetStainlessLang() {
+	m.stainless_lang = nil
+	delete(m.clearedFields, accountfingerprint.FieldStainlessLang)
+}
+
+// SetStainlessPackageVersion sets the "stainless_package_version" field.
+func (m *AccountFingerprintMutation) SetStainlessPackageVersion(s string) {
+	m.stainless_package_version = &s
+}
+
+// StainlessPackageVersion returns the value of the "stainless_package_version" field in the mutation.
+func (m *AccountFingerprintMutation) StainlessPackageVersion() (r string, exists bool) {
+	v := m.stainless_package_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStainlessPackageVersion returns the old "stainless_package_version" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldStainlessPackageVersion(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStainlessPackageVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStainlessPackageVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStainlessPackageVersion: %w", err)
+	}
+	return oldValue.StainlessPackageVersion, nil
+}
+
+// ClearStainlessPackageVersion clears the value of the "stainless_package_version" field.
+func (m *AccountFingerprintMutation) ClearStainlessPackageVersion() {
+	m.stainless_package_version = nil
+	m.clearedFields[accountfingerprint.FieldStainlessPackageVersion] = struct{}{}
+}
+
+// StainlessPackageVersionCleared returns if the "stainless_package_version" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) StainlessPackageVersionCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldStainlessPackageVersion]
+	return ok
+}
+
+// ResetStainlessPackageVersion resets all changes to the "stainless_package_version" field.
+func (m *AccountFingerprintMutation) ResetStainlessPackageVersion() {
+	m.stainless_package_version = nil
+	delete(m.clearedFields, accountfingerprint.FieldStainlessPackageVersion)
+}
+
+// SetStainlessOs sets the "stainless_os" field.
+func (m *AccountFingerprintMutation) SetStainlessOs(s string) {
+	m.stainless_os = &s
+}
+
+// StainlessOs returns the value of the "stainless_os" field in the mutation.
+func (m *AccountFingerprintMutation) StainlessOs() (r string, exists bool) {
+	v := m.stainless_os
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStainlessOs returns the old "stainless_os" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldStainlessOs(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStainlessOs is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStainlessOs requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStainlessOs: %w", err)
+	}
+	return oldValue.StainlessOs, nil
+}
+
+// ClearStainlessOs clears the value of the "stainless_os" field.
+func (m *AccountFingerprintMutation) ClearStainlessOs() {
+	m.stainless_os = nil
+	m.clearedFields[accountfingerprint.FieldStainlessOs] = struct{}{}
+}
+
+// StainlessOsCleared returns if the "stainless_os" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) StainlessOsCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldStainlessOs]
+	return ok
+}
+
+// ResetStainlessOs resets all changes to the "stainless_os" field.
+func (m *AccountFingerprintMutation) ResetStainlessOs() {
+	m.stainless_os = nil
+	delete(m.clearedFields, accountfingerprint.FieldStainlessOs)
+}
+
+// SetStainlessArch sets the "stainless_arch" field.
+func (m *AccountFingerprintMutation) SetStainlessArch(s string) {
+	m.stainless_arch = &s
+}
+
+// StainlessArch returns the value of the "stainless_arch" field in the mutation.
+func (m *AccountFingerprintMutation) StainlessArch() (r string, exists bool) {
+	v := m.stainless_arch
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStainlessArch returns the old "stainless_arch" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldStainlessArch(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStainlessArch is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStainlessArch requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStainlessArch: %w", err)
+	}
+	return oldValue.StainlessArch, nil
+}
+
+// ClearStainlessArch clears the value of the "stainless_arch" field.
+func (m *AccountFingerprintMutation) ClearStainlessArch() {
+	m.stainless_arch = nil
+	m.clearedFields[accountfingerprint.FieldStainlessArch] = struct{}{}
+}
+
+// StainlessArchCleared returns if the "stainless_arch" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) StainlessArchCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldStainlessArch]
+	return ok
+}
+
+// ResetStainlessArch resets all changes to the "stainless_arch" field.
+func (m *AccountFingerprintMutation) ResetStainlessArch() {
+	m.stainless_arch = nil
+	delete(m.clearedFields, accountfingerprint.FieldStainlessArch)
+}
+
+// SetStainlessRuntime sets the "stainless_runtime" field.
+func (m *AccountFingerprintMutation) SetStainlessRuntime(s string) {
+	m.stainless_runtime = &s
+}
+
+// StainlessRuntime returns the value of the "stainless_runtime" field in the mutation.
+func (m *AccountFingerprintMutation) StainlessRuntime() (r string, exists bool) {
+	v := m.stainless_runtime
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStainlessRuntime returns the old "stainless_runtime" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldStainlessRuntime(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStainlessRuntime is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStainlessRuntime requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStainlessRuntime: %w", err)
+	}
+	return oldValue.StainlessRuntime, nil
+}
+
+// ClearStainlessRuntime clears the value of the "stainless_runtime" field.
+func (m *AccountFingerprintMutation) ClearStainlessRuntime() {
+	m.stainless_runtime = nil
+	m.clearedFields[accountfingerprint.FieldStainlessRuntime] = struct{}{}
+}
+
+// StainlessRuntimeCleared returns if the "stainless_runtime" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) StainlessRuntimeCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldStainlessRuntime]
+	return ok
+}
+
+// ResetStainlessRuntime resets all changes to the "stainless_runtime" field.
+func (m *AccountFingerprintMutation) ResetStainlessRuntime() {
+	m.stainless_runtime = nil
+	delete(m.clearedFields, accountfingerprint.FieldStainlessRuntime)
+}
+
+// SetStainlessRuntimeVersion sets the "stainless_runtime_version" field.
+func (m *AccountFingerprintMutation) SetStainlessRuntimeVersion(s string) {
+	m.stainless_runtime_version = &s
+}
+
+// StainlessRuntimeVersion returns the value of the "stainless_runtime_version" field in the mutation.
+func (m *AccountFingerprintMutation) StainlessRuntimeVersion() (r string, exists bool) {
+	v := m.stainless_runtime_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStainlessRuntimeVersion returns the old "stainless_runtime_version" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldStainlessRuntimeVersion(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStainlessRuntimeVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStainlessRuntimeVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStainlessRuntimeVersion: %w", err)
+	}
+	return oldValue.StainlessRuntimeVersion, nil
+}
+
+// ClearStainlessRuntimeVersion clears the value of the "stainless_runtime_version" field.
+func (m *AccountFingerprintMutation) ClearStainlessRuntimeVersion() {
+	m.stainless_runtime_version = nil
+	m.clearedFields[accountfingerprint.FieldStainlessRuntimeVersion] = struct{}{}
+}
+
+// StainlessRuntimeVersionCleared returns if the "stainless_runtime_version" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) StainlessRuntimeVersionCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldStainlessRuntimeVersion]
+	return ok
+}
+
+// ResetStainlessRuntimeVersion resets all changes to the "stainless_runtime_version" field.
+func (m *AccountFingerprintMutation) ResetStainlessRuntimeVersion() {
+	m.stainless_runtime_version = nil
+	delete(m.clearedFields, accountfingerprint.FieldStainlessRuntimeVersion)
+}
+
+// SetFingerprintCreatedAt sets the "fingerprint_created_at" field.
+func (m *AccountFingerprintMutation) SetFingerprintCreatedAt(t time.Time) {
+	m.fingerprint_created_at = &t
+}
+
+// FingerprintCreatedAt returns the value of the "fingerprint_created_at" field in the mutation.
+func (m *AccountFingerprintMutation) FingerprintCreatedAt() (r time.Time, exists bool) {
+	v := m.fingerprint_created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFingerprintCreatedAt returns the old "fingerprint_created_at" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldFingerprintCreatedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFingerprintCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFingerprintCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFingerprintCreatedAt: %w", err)
+	}
+	return oldValue.FingerprintCreatedAt, nil
+}
+
+// ClearFingerprintCreatedAt clears the value of the "fingerprint_created_at" field.
+func (m *AccountFingerprintMutation) ClearFingerprintCreatedAt() {
+	m.fingerprint_created_at = nil
+	m.clearedFields[accountfingerprint.FieldFingerprintCreatedAt] = struct{}{}
+}
+
+// FingerprintCreatedAtCleared returns if the "fingerprint_created_at" field was cleared in this mutation.
+func (m *AccountFingerprintMutation) FingerprintCreatedAtCleared() bool {
+	_, ok := m.clearedFields[accountfingerprint.FieldFingerprintCreatedAt]
+	return ok
+}
+
+// ResetFingerprintCreatedAt resets all changes to the "fingerprint_created_at" field.
+func (m *AccountFingerprintMutation) ResetFingerprintCreatedAt() {
+	m.fingerprint_created_at = nil
+	delete(m.clearedFields, accountfingerprint.FieldFingerprintCreatedAt)
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *AccountFingerprintMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *AccountFingerprintMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the AccountFingerprint entity.
+// If the AccountFingerprint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountFingerprintMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *AccountFingerprintMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// Where appends a list predicates to the AccountFingerprintMutation builder.
+func (m *AccountFingerprintMutation) Where(ps ...predicate.AccountFingerprint) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the AccountFingerprintMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *AccountFingerprintMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.AccountFingerprint, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *AccountFingerprintMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *AccountFingerprintMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (AccountFingerprint).
+func (m *AccountFingerprintMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *AccountFingerprintMutation) Fields() []string {
+	fields := make([]string, 0, 12)
+	if m.account_id != nil {
+		fields = append(fields, accountfingerprint.FieldAccountID)
+	}
+	if m.schema_version != nil {
+		fields = append(fields, accountfingerprint.FieldSchemaVersion)
+	}
+	if m.client_id != nil {
+		fields = append(fields, accountfingerprint.FieldClientID)
+	}
+	if m.user_agent != nil {
+		fields = append(fields, accountfingerprint.FieldUserAgent)
+	}
+	if m.stainless_lang != nil {
+		fields = append(fields, accountfingerprint.FieldStainlessLang)
+	}
+	if m.stainless_package_version != nil {
+		fields = append(fields, accountfingerprint.FieldStainlessPackageVersion)
+	}
+	if m.stainless_os != nil {
+		fields = append(fields, accountfingerprint.FieldStainlessOs)
+	}
+	if m.stainless_arch != nil {
+		fields = append(fields, accountfingerprint.FieldStainlessArch)
+	}
+	if m.stainless_runtime != nil {
+		fields = append(fields, accountfingerprint.FieldStainlessRuntime)
+	}
+	if m.stainless_runtime_version != nil {
+		fields = append(fields, accountfingerprint.FieldStainlessRuntimeVersion)
+	}
+	if m.fingerprint_created_at != nil {
+		fields = append(fields, accountfingerprint.FieldFingerprintCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, accountfingerprint.FieldUpdatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *AccountFingerprintMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case accountfingerprint.FieldAccountID:
+		return m.AccountID()
+	case accountfingerprint.FieldSchemaVersion:
+		return m.SchemaVersion()
+	case accountfingerprint.FieldClientID:
+		return m.ClientID()
+	case accountfingerprint.FieldUserAgent:
+		return m.UserAgent()
+	case accountfingerprint.FieldStainlessLang:
+		return m.StainlessLang()
+	case accountfingerprint.FieldStainlessPackageVersion:
+		return m.StainlessPackageVersion()
+	case accountfingerprint.FieldStainlessOs:
+		return m.StainlessOs()
+	case accountfingerprint.FieldStainlessArch:
+		return m.StainlessArch()
+	case accountfingerprint.FieldStainlessRuntime:
+		return m.StainlessRuntime()
+	case accountfingerprint.FieldStainlessRuntimeVersion:
+		return m.StainlessRuntimeVersion()
+	case accountfingerprint.FieldFingerprintCreatedAt:
+		return m.FingerprintCreatedAt()
+	case accountfingerprint.FieldUpdatedAt:
+		return m.UpdatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *AccountFingerprintMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case accountfingerprint.FieldAccountID:
+		return m.OldAccountID(ctx)
+	case accountfingerprint.FieldSchemaVersion:
+		return m.OldSchemaVersion(ctx)
+	case accountfingerprint.FieldClientID:
+		return m.OldClientID(ctx)
+	case accountfingerprint.FieldUserAgent:
+		return m.OldUserAgent(ctx)
+	case accountfingerprint.FieldStainlessLang:
+		return m.OldStainlessLang(ctx)
+	case accountfingerprint.FieldStainlessPackageVersion:
+		return m.OldStainlessPackageVersion(ctx)
+	case accountfingerprint.FieldStainlessOs:
+		return m.OldStainlessOs(ctx)
+	case accountfingerprint.FieldStainlessArch:
+		return m.OldStainlessArch(ctx)
+	case accountfingerprint.FieldStainlessRuntime:
+		return m.OldStainlessRuntime(ctx)
+	case accountfingerprint.FieldStainlessRuntimeVersion:
+		return m.OldStainlessRuntimeVersion(ctx)
+	case accountfingerprint.FieldFingerprintCreatedAt:
+		return m.OldFingerprintCreatedAt(ctx)
+	case accountfingerprint.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown AccountFingerprint field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *AccountFingerprintMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case accountfingerprint.FieldAccountID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAccountID(v)
+		return nil
+	case accountfingerprint.FieldSchemaVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSchemaVersion(v)
+		return nil
+	case accountfingerprint.FieldClientID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClientID(v)
+		return nil
+	case accountfingerprint.FieldUserAgent:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserAgent(v)
+		return nil
+	case accountfingerprint.FieldStainlessLang:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStainlessLang(v)
+		return nil
+	case accountfingerprint.FieldStainlessPackageVersion:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStainlessPackageVersion(v)
+		return nil
+	case accountfingerprint.FieldStainlessOs:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStainlessOs(v)
+		return nil
+	case accountfingerprint.FieldStainlessArch:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStainlessArch(v)
+		return nil
+	case accountfingerprint.FieldStainlessRuntime:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStainlessRuntime(v)
+		return nil
+	case accountfingerprint.FieldStainlessRuntimeVersion:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStainlessRuntimeVersion(v)
+		return nil
+	case accountfingerprint.FieldFingerprintCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFingerprintCreatedAt(v)
+		return nil
+	case accountfingerprint.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown AccountFingerprint field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *AccountFingerprintMutation) AddedFields() []string {
+	var fields []string
+	if m.addaccount_id != nil {
+		fields = append(fields, accountfingerprint.FieldAccountID)
+	}
+	if m.addschema_version != nil {
+		fields = append(fields, accountfingerprint.FieldSchemaVersion)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *AccountFingerprintMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case accountfingerprint.FieldAccountID:
+		return m.AddedAccountID()
+	case accountfingerprint.FieldSchemaVersion:
+		return m.AddedSchemaVersion()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *AccountFingerprintMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case accountfingerprint.FieldAccountID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAccountID(v)
+		return nil
+	case accountfingerprint.FieldSchemaVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddSchemaVersion(v)
+		return nil
+	}
+	return fmt.Errorf("unknown AccountFingerprint numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *AccountFingerprintMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(accountfingerprint.FieldClientID) {
+		fields = append(fields, accountfingerprint.FieldClientID)
+	}
+	if m.FieldCleared(accountfingerprint.FieldUserAgent) {
+		fields = append(fields, accountfingerprint.FieldUserAgent)
+	}
+	if m.FieldCleared(accountfingerprint.FieldStainlessLang) {
+		fields = append(fields, accountfingerprint.FieldStainlessLang)
+	}
+	if m.FieldCleared(accountfingerprint.FieldStainlessPackageVersion) {
+		fields = append(fields, accountfingerprint.FieldStainlessPackageVersion)
+	}
+	if m.FieldCleared(accountfingerprint.FieldStainlessOs) {
+		fields = append(fields, accountfingerprint.FieldStainlessOs)
+	}
+	if m.FieldCleared(accountfingerprint.FieldStainlessArch) {
+		fields = append(fields, accountfingerprint.FieldStainlessArch)
+	}
+	if m.FieldCleared(accountfingerprint.FieldStainlessRuntime) {
+		fields = append(fields, accountfingerprint.FieldStainlessRuntime)
+	}
+	if m.FieldCleared(accountfingerprint.FieldStainlessRuntimeVersion) {
+		fields = append(fields, accountfingerprint.FieldStainlessRuntimeVersion)
+	}
+	if m.FieldCleared(accountfingerprint.FieldFingerprintCreatedAt) {
+		fields = append(fields, accountfingerprint.FieldFingerprintCreatedAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *AccountFingerprintMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *AccountFingerprintMutation) ClearField(name string) error {
+	switch name {
+	case accountfingerprint.FieldClientID:
+		m.ClearClientID()
+		return nil
+	case accountfingerprint.FieldUserAgent:
+		m.ClearUserAgent()
+		return nil
+	case accountfingerprint.FieldStainlessLang:
+		m.ClearStainlessLang()
+		return nil
+	case accountfingerprint.FieldStainlessPackageVersion:
+		m.ClearStainlessPackageVersion()
+		return nil
+	case accountfingerprint.FieldStainlessOs:
+		m.ClearStainlessOs()
+		return nil
+	case accountfingerprint.FieldStainlessArch:
+		m.ClearStainlessArch()
+		return nil
+	case accountfingerprint.FieldStainlessRuntime:
+		m.ClearStainlessRuntime()
+		return nil
+	case accountfingerprint.FieldStainlessRuntimeVersion:
+		m.ClearStainlessRuntimeVersion()
+		return nil
+	case accountfingerprint.FieldFingerprintCreatedAt:
+		m.ClearFingerprintCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown AccountFingerprint nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *AccountFingerprintMutation) ResetField(name string) error {
+	switch name {
+	case accountfingerprint.FieldAccountID:
+		m.ResetAccountID()
+		return nil
+	case accountfingerprint.FieldSchemaVersion:
+		m.ResetSchemaVersion()
+		return nil
+	case accountfingerprint.FieldClientID:
+		m.ResetClientID()
+		return nil
+	case accountfingerprint.FieldUserAgent:
+		m.ResetUserAgent()
+		return nil
+	case accountfingerprint.FieldStainlessLang:
+		m.ResetStainlessLang()
+		return nil
+	case accountfingerprint.FieldStainlessPackageVersion:
+		m.ResetStainlessPackageVersion()
+		return nil
+	case accountfingerprint.FieldStainlessOs:
+		m.ResetStainlessOs()
+		return nil
+	case accountfingerprint.FieldStainlessArch:
+		m.ResetStainlessArch()
+		return nil
+	case accountfingerprint.FieldStainlessRuntime:
+		m.ResetStainlessRuntime()
+		return nil
+	case accountfingerprint.FieldStainlessRuntimeVersion:
+		m.ResetStainlessRuntimeVersion()
+		return nil
+	case accountfingerprint.FieldFingerprintCreatedAt:
+		m.ResetFingerprintCreatedAt()
+		return nil
+	case accountfingerprint.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown AccountFingerprint field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *AccountFingerprintMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *AccountFingerprintMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *AccountFingerprintMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *AccountFingerprintMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *AccountFingerprintMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *AccountFingerprintMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *AccountFingerprintMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown AccountFingerprint unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *AccountFingerprintMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown AccountFingerprint edge %s", name)
+}
+
 // AccountGroupMutation represents an operation that mutates the AccountGroup nodes in the graph.
 type AccountGroupMutation struct {
 	config
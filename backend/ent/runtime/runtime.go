@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/ent/account"
+	"github.com/Wei-Shaw/sub2api/ent/accountfingerprint"
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
 	"github.com/Wei-Shaw/sub2api/ent/group"
@@ -189,6 +190,18 @@ func init() {
 	accountDescSessionWindowStatus := accountFields[18].Descriptor()
 	// account.SessionWindowStatusValidator is a validator for the "session_window_status" field. It is called by the builders before save.
 	account.SessionWindowStatusValidator = accountDescSessionWindowStatus.Validators[0].(func(string) error)
+	accountfingerprintFields := schema.AccountFingerprint{}.Fields()
+	_ = accountfingerprintFields
+	// accountfingerprintDescSchemaVersion is the schema descriptor for schema_version field.
+	accountfingerprintDescSchemaVersion := accountfingerprintFields[1].Descriptor()
+	// accountfingerprint.DefaultSchemaVersion holds the default value on creation for the schema_version field.
+	accountfingerprint.DefaultSchemaVersion = accountfingerprintDescSchemaVersion.Default.(int)
+	// accountfingerprintDescUpdatedAt is the schema descriptor for updated_at field.
+	accountfingerprintDescUpdatedAt := accountfingerprintFields[11].Descriptor()
+	// accountfingerprint.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	accountfingerprint.DefaultUpdatedAt = accountfingerprintDescUpdatedAt.Default.(func() time.Time)
+	// accountfingerprint.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	accountfingerprint.UpdateDefaultUpdatedAt = accountfingerprintDescUpdatedAt.UpdateDefault.(func() time.Time)
 	accountgroupFields := schema.AccountGroup{}.Fields()
 	_ = accountgroupFields
 	// accountgroupDescPriority is the schema descriptor for priority field.
@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// AccountFingerprint holds the schema definition for the AccountFingerprint entity.
+//
+// 这是账号指纹（service.Fingerprint）的持久化副本：Redis 缓存（identity_cache.go）设有 TTL，
+// 一旦被清空，账号会重新生成 ClientID，导致上游会话身份不连续。该表作为兜底的事实来源，
+// 由 service.PersistentIdentityCache 在缓存未命中时读取，并在写入时同步写库。
+//
+// 删除策略：硬删除（无软删除字段，不保留历史版本）。该表没有指向账号的外键，删除账号时
+// 不会同时删除对应的指纹行。
+type AccountFingerprint struct {
+	ent.Schema
+}
+
+func (AccountFingerprint) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "account_fingerprints"},
+	}
+}
+
+func (AccountFingerprint) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("account_id").
+			Unique(),
+		field.Int("schema_version").
+			Default(0),
+		field.String("client_id").
+			Optional(),
+		field.String("user_agent").
+			Optional(),
+		field.String("stainless_lang").
+			Optional(),
+		field.String("stainless_package_version").
+			Optional(),
+		field.String("stainless_os").
+			Optional(),
+		field.String("stainless_arch").
+			Optional(),
+		field.String("stainless_runtime").
+			Optional(),
+		field.String("stainless_runtime_version").
+			Optional(),
+		field.Time("fingerprint_created_at").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{
+				dialect.Postgres: "timestamptz",
+			}),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			SchemaType(map[string]string{
+				dialect.Postgres: "timestamptz",
+			}),
+	}
+}
+
+func (AccountFingerprint) Indexes() []ent.Index {
+	// account_id 已在 Fields() 中声明 Unique()，无需额外索引
+	return nil
+}
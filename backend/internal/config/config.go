@@ -214,6 +214,10 @@ type GatewayConfig struct {
 	// API-key 账号在客户端未提供 anthropic-beta 时，是否按需自动补齐（默认关闭以保持兼容）
 	InjectBetaForAPIKey bool `mapstructure:"inject_beta_for_apikey"`
 
+	// 是否将客户端发来的 anthropic-beta 与必需的 flag 合并（去重后并集），而非直接覆盖
+	// 默认关闭，保持覆盖行为不变
+	MergeClientAnthropicBeta bool `mapstructure:"merge_client_anthropic_beta"`
+
 	// 是否允许对部分 400 错误触发 failover（默认关闭以避免改变语义）
 	FailoverOn400 bool `mapstructure:"failover_on_400"`
 
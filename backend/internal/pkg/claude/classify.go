@@ -0,0 +1,110 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// RequestDecision 汇总单次请求的检测/路由信号，供代理层一次性决策使用，
+// 避免在多处重复解析请求体、重复调用各检测函数。
+type RequestDecision struct {
+	IsRealClaudeCode   bool    // system 提示是否与已知 Claude Code 模板足够相似
+	Grade              Grade   // 三档判定：genuine/suspicious/not-genuine，供监控面板统计
+	TemplateSimilarity float64 // system 提示与最匹配模板的相似度分数
+	HeaderScore        float64 // 请求头与真实 Claude Code 客户端 header 集合的匹配程度
+	ToolScore          float64 // tools 字段与真实 Claude Code 内置工具集合的匹配程度
+	Model              string  // 请求的模型名称
+	Stream             bool    // 是否为流式请求
+	NeedsUserIDRewrite bool    // body 中是否携带需要重写的 metadata.user_id
+	IsCountTokens      bool    // 是否为 count_tokens 请求，供上游选择对应的 Anthropic-Beta 头
+}
+
+// classifyRequestBody 是 ClassifyRequest 解析请求体时使用的最小结构。
+type classifyRequestBody struct {
+	Model     string        `json:"model"`
+	Stream    bool          `json:"stream"`
+	System    any           `json:"system"`
+	Tools     []interface{} `json:"tools"`
+	MaxTokens *int          `json:"max_tokens"`
+	Metadata  struct {
+		UserID string `json:"user_id"`
+	} `json:"metadata"`
+}
+
+// isCountTokensRequest 判断请求是否为 /messages/count_tokens 端点。count_tokens 与 messages
+// 请求共享 system/messages 结构，做门禁与 fingerprint 处理时无需区分，但 count_tokens 请求
+// 不带 max_tokens 字段（该字段在 messages 请求中是必填项），可作为没有请求路径时的形状兜底信号。
+func isCountTokensRequest(req *http.Request, parsed classifyRequestBody) bool {
+	if req != nil && strings.HasSuffix(req.URL.Path, "/count_tokens") {
+		return true
+	}
+	return req == nil && parsed.MaxTokens == nil && parsed.Model != ""
+}
+
+// ClassifyRequest 综合 header 与 body 中的信号，为一次请求生成路由决策。
+// body 解析失败时返回的 RequestDecision 各字段保持零值（视为非 Claude Code、非流式）。
+func ClassifyRequest(req *http.Request, body []byte) RequestDecision {
+	ctx := context.Background()
+	if req != nil {
+		ctx = req.Context()
+	}
+	_, span := activeTracer.StartSpan(ctx, "claude.ClassifyRequest")
+	defer span.End()
+
+	var parsed classifyRequestBody
+	_ = json.Unmarshal(body, &parsed)
+
+	decision := RequestDecision{
+		TemplateSimilarity: BestSimilarityByTemplates(parsed.System),
+		ToolScore:          ToolSetSimilarity(parsed.Tools),
+		Model:              parsed.Model,
+		Stream:             parsed.Stream,
+		NeedsUserIDRewrite: parsed.Metadata.UserID != "",
+		IsCountTokens:      isCountTokensRequest(req, parsed),
+	}
+	decision.IsRealClaudeCode = decision.TemplateSimilarity >= DefaultSystemPromptThreshold
+	decision.Grade = GradeForSimilarity(decision.TemplateSimilarity, DefaultSystemPromptThreshold)
+
+	if req != nil {
+		decision.HeaderScore = HeaderFingerprintScore(req.Header)
+	}
+
+	span.SetAttribute("genuine", decision.IsRealClaudeCode)
+	span.SetAttribute("score", decision.TemplateSimilarity)
+
+	return decision
+}
+
+// ThresholdProvider 按 accountID 解析该账号的系统提示相似度阈值覆盖值。
+// ok 为 false 表示该账号未设置覆盖，调用方应回退到 DefaultSystemPromptThreshold。
+type ThresholdProvider func(accountID int64) (threshold float64, ok bool)
+
+// ClassifyRequestForAccount 与 ClassifyRequest 行为一致，但 IsRealClaudeCode 的判定
+// 使用 provider 解析出的 accountID 专属阈值，而非全局的 DefaultSystemPromptThreshold。
+// provider 为 nil 或未返回覆盖值时，行为与 ClassifyRequest 完全一致。
+func ClassifyRequestForAccount(req *http.Request, body []byte, accountID int64, provider ThresholdProvider) RequestDecision {
+	decision := ClassifyRequest(req, body)
+
+	threshold := DefaultSystemPromptThreshold
+	if provider != nil {
+		if override, ok := provider(accountID); ok {
+			threshold = override
+		}
+	}
+	decision.IsRealClaudeCode = decision.TemplateSimilarity >= threshold
+	decision.Grade = GradeForSimilarity(decision.TemplateSimilarity, threshold)
+
+	return decision
+}
+
+// ClassifyRequestWithCounters 与 ClassifyRequest 行为一致，并额外将本次判定结果记录到 counters。
+// counters 为 nil 时等价于 ClassifyRequest。
+func ClassifyRequestWithCounters(req *http.Request, body []byte, counters *DetectionCounters) RequestDecision {
+	decision := ClassifyRequest(req, body)
+	if counters != nil {
+		counters.Record(decision.Grade)
+	}
+	return decision
+}
@@ -0,0 +1,107 @@
+package claude
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyRequest_RealStreamingRequest(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4-20250514","stream":true,"system":"You are Claude Code, Anthropic's official CLI for Claude.","tools":[{"name":"Bash"},{"name":"Read"},{"name":"Edit"}],"metadata":{"user_id":"user_abc_account__session_11111111-1111-1111-1111-111111111111"}}`)
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/messages", nil)
+	assert.NoError(t, err)
+	for name, value := range DefaultHeaders {
+		req.Header.Set(name, value)
+	}
+
+	decision := ClassifyRequest(req, body)
+
+	assert.True(t, decision.IsRealClaudeCode)
+	assert.Equal(t, 1.0, decision.HeaderScore)
+	assert.Greater(t, decision.ToolScore, 0.0)
+	assert.Equal(t, "claude-sonnet-4-20250514", decision.Model)
+	assert.True(t, decision.Stream)
+	assert.True(t, decision.NeedsUserIDRewrite)
+}
+
+func TestClassifyRequestForAccount_DifferentThresholdsClassifyBorderlinePromptDifferently(t *testing.T) {
+	// A prompt similar enough to pass a lenient threshold but not a strict one.
+	body := []byte(`{"model":"claude-sonnet-4-20250514","system":"You are Claude Code, an official CLI for Claude."}`)
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/messages", nil)
+	assert.NoError(t, err)
+
+	similarity := BestSimilarityByTemplates(`You are Claude Code, an official CLI for Claude.`)
+	strict := similarity + 0.01
+	lenient := similarity - 0.01
+
+	thresholds := map[int64]float64{1: strict, 2: lenient}
+	provider := func(accountID int64) (float64, bool) {
+		t, ok := thresholds[accountID]
+		return t, ok
+	}
+
+	strictDecision := ClassifyRequestForAccount(req, body, 1, provider)
+	lenientDecision := ClassifyRequestForAccount(req, body, 2, provider)
+
+	assert.False(t, strictDecision.IsRealClaudeCode)
+	assert.True(t, lenientDecision.IsRealClaudeCode)
+}
+
+func TestClassifyRequestForAccount_NoOverrideFallsBackToDefault(t *testing.T) {
+	body := []byte(`{"system":"You are Claude Code, Anthropic's official CLI for Claude."}`)
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/messages", nil)
+	assert.NoError(t, err)
+
+	decision := ClassifyRequestForAccount(req, body, 42, func(int64) (float64, bool) { return 0, false })
+
+	assert.True(t, decision.IsRealClaudeCode)
+}
+
+func TestClassifyRequest_CountTokensEndpointFlagged(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4-20250514","system":"You are Claude Code, Anthropic's official CLI for Claude.","messages":[{"role":"user","content":"hi"}]}`)
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/messages/count_tokens", nil)
+	assert.NoError(t, err)
+
+	decision := ClassifyRequest(req, body)
+
+	assert.True(t, decision.IsCountTokens)
+	assert.True(t, decision.IsRealClaudeCode, "count_tokens shares gating/fingerprint handling with messages requests")
+}
+
+func TestClassifyRequest_MessagesEndpointNotFlaggedAsCountTokens(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4-20250514","max_tokens":1024,"system":"You are Claude Code, Anthropic's official CLI for Claude."}`)
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/messages", nil)
+	assert.NoError(t, err)
+
+	decision := ClassifyRequest(req, body)
+
+	assert.False(t, decision.IsCountTokens)
+}
+
+func TestClassifyRequest_CountTokensShapeFallbackWithoutRequest(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4-20250514","system":"You are Claude Code, Anthropic's official CLI for Claude."}`)
+
+	decision := ClassifyRequest(nil, body)
+
+	assert.True(t, decision.IsCountTokens, "no request and no max_tokens field should fall back to shape detection")
+}
+
+func TestClassifyRequest_FakeNonStreamingRequest(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4-20250514","stream":false,"system":"You are a generic AI assistant."}`)
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/messages", nil)
+	assert.NoError(t, err)
+	req.Header.Set("User-Agent", "curl/8.0")
+
+	decision := ClassifyRequest(req, body)
+
+	assert.False(t, decision.IsRealClaudeCode)
+	assert.Less(t, decision.HeaderScore, 0.5)
+	assert.Equal(t, "claude-sonnet-4-20250514", decision.Model)
+	assert.False(t, decision.Stream)
+	assert.False(t, decision.NeedsUserIDRewrite)
+}
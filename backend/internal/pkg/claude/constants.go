@@ -1,6 +1,8 @@
 // Package claude provides constants and helpers for Claude API integration.
 package claude
 
+import "strings"
+
 // Claude Code 客户端相关常量
 
 // Beta header 常量
@@ -28,6 +30,33 @@ const APIKeyBetaHeader = BetaClaudeCode + "," + BetaInterleavedThinking + "," +
 // APIKeyHaikuBetaHeader Haiku 模型在 API-key 账号下使用的 anthropic-beta header（不包含 oauth / claude-code）
 const APIKeyHaikuBetaHeader = BetaInterleavedThinking
 
+// MergeAnthropicBetaHeader 合并客户端发来的 anthropic-beta 与必需的 flag 列表，
+// 返回去重后的并集（保留客户端 flag 在前的相对顺序，必需 flag 追加在后）。
+// 用于替代直接覆盖模式，避免丢弃客户端合法请求的 beta 功能。
+func MergeAnthropicBetaHeader(clientBeta, required string) string {
+	seen := make(map[string]struct{})
+	var merged []string
+
+	appendFlags := func(csv string) {
+		for _, flag := range strings.Split(csv, ",") {
+			flag = strings.TrimSpace(flag)
+			if flag == "" {
+				continue
+			}
+			if _, ok := seen[flag]; ok {
+				continue
+			}
+			seen[flag] = struct{}{}
+			merged = append(merged, flag)
+		}
+	}
+
+	appendFlags(clientBeta)
+	appendFlags(required)
+
+	return strings.Join(merged, ",")
+}
+
 // DefaultHeaders 是 Claude Code 客户端默认请求头。
 var DefaultHeaders = map[string]string{
 	"User-Agent":                                "claude-cli/2.0.62 (external, cli)",
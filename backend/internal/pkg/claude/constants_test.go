@@ -0,0 +1,26 @@
+package claude
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeAnthropicBetaHeader_UnionsAndDedupes(t *testing.T) {
+	merged := MergeAnthropicBetaHeader("custom-beta-1,"+BetaClaudeCode, ClaudeCodeBetaHeader)
+
+	assert.Contains(t, merged, "custom-beta-1")
+	assert.Contains(t, merged, BetaClaudeCode)
+	assert.Contains(t, merged, BetaOAuth)
+	assert.Contains(t, merged, BetaInterleavedThinking)
+	assert.Contains(t, merged, BetaFineGrainedToolStreaming)
+
+	// BetaClaudeCode appears in both client and required lists; must not be duplicated.
+	assert.Equal(t, 1, strings.Count(merged, BetaClaudeCode))
+}
+
+func TestMergeAnthropicBetaHeader_EmptyClient(t *testing.T) {
+	merged := MergeAnthropicBetaHeader("", ClaudeCodeBetaHeader)
+	assert.Equal(t, ClaudeCodeBetaHeader, merged)
+}
@@ -0,0 +1,40 @@
+package claude
+
+import "sync/atomic"
+
+// DetectionCounters 以原子计数器统计各档检测结果的出现次数，
+// 供监控面板周期性拉取，而无需为每次请求单独打日志。
+// 零值即可用。
+type DetectionCounters struct {
+	genuine    atomic.Int64
+	suspicious atomic.Int64
+	notGenuine atomic.Int64
+}
+
+// DetectionCounterSnapshot 是某一时刻各档计数的只读快照
+type DetectionCounterSnapshot struct {
+	Genuine    int64
+	Suspicious int64
+	NotGenuine int64
+}
+
+// Record 将一次判定结果计入对应档位的计数器，并发安全
+func (c *DetectionCounters) Record(grade Grade) {
+	switch grade {
+	case GradeGenuine:
+		c.genuine.Add(1)
+	case GradeSuspicious:
+		c.suspicious.Add(1)
+	default:
+		c.notGenuine.Add(1)
+	}
+}
+
+// Snapshot 返回当前各档计数的快照
+func (c *DetectionCounters) Snapshot() DetectionCounterSnapshot {
+	return DetectionCounterSnapshot{
+		Genuine:    c.genuine.Load(),
+		Suspicious: c.suspicious.Load(),
+		NotGenuine: c.notGenuine.Load(),
+	}
+}
@@ -0,0 +1,42 @@
+package claude
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectionCounters_ConcurrentRecordingTotalsCorrectly(t *testing.T) {
+	var counters DetectionCounters
+
+	const perGrade = 200
+	var wg sync.WaitGroup
+	record := func(grade Grade) {
+		defer wg.Done()
+		for i := 0; i < perGrade; i++ {
+			counters.Record(grade)
+		}
+	}
+
+	wg.Add(3)
+	go record(GradeGenuine)
+	go record(GradeSuspicious)
+	go record(GradeNotGenuine)
+	wg.Wait()
+
+	snapshot := counters.Snapshot()
+	assert.Equal(t, int64(perGrade), snapshot.Genuine)
+	assert.Equal(t, int64(perGrade), snapshot.Suspicious)
+	assert.Equal(t, int64(perGrade), snapshot.NotGenuine)
+}
+
+func TestClassifyRequestWithCounters_RecordsGrade(t *testing.T) {
+	var counters DetectionCounters
+	body := []byte(`{"system":"You are Claude Code, Anthropic's official CLI for Claude."}`)
+
+	ClassifyRequestWithCounters(nil, body, &counters)
+
+	snapshot := counters.Snapshot()
+	assert.Equal(t, int64(1), snapshot.Genuine)
+}
@@ -0,0 +1,394 @@
+package claude
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// DefaultSystemPromptThreshold 是判定请求为真实 Claude Code 客户端的默认相似度阈值
+const DefaultSystemPromptThreshold = 0.5
+
+// CanonicalSystemPrompt 是内置的、最标准的 Claude Code 系统提示文案，供 PolicyInject
+// 策略在判定请求非真实 Claude Code 时用来替换 system 字段。
+const CanonicalSystemPrompt = "You are Claude Code, Anthropic's official CLI for Claude."
+
+// thinkingModeSystemPromptPlaceholder 是扩展思考（extended-thinking）模式提示的占位模板。
+// 真正的思考模式提示词一经确认即可通过 RegisterSystemPromptTemplate 或替换本常量加入。
+const thinkingModeSystemPromptPlaceholder = "You are Claude Code, Anthropic's official CLI for Claude. [thinking-mode]"
+
+// defaultMatcher 是包级检测函数使用的默认 TemplateMatcher 实例，内置已知的 Claude Code
+// 系统提示模板。RegisterSystemPromptTemplate 等包级函数都只是对 defaultMatcher 对应方法
+// 的委托，保留是为了兼容既有调用方；需要在运行时从配置加载/热更新模板列表的场景，应直接
+// 构造独立的 TemplateMatcher 实例，而不是绕过它去改这里的内置列表。
+var defaultMatcher = NewTemplateMatcher([]string{
+	CanonicalSystemPrompt,
+	// thinkingModeSystemPromptPlaceholder 占位模板：扩展思考模式下 Claude Code
+	// 可能发送略有差异的系统提示。真实文案确认后应替换本条，而非新增分支逻辑。
+	thinkingModeSystemPromptPlaceholder,
+})
+
+// RegisterSystemPromptTemplate 注册一个新的系统提示模板，用于扩展检测覆盖范围
+// （例如新的客户端变体），无需修改内置模板列表或重新编译调用方。
+// 若新模板与已有模板的相似度超过 nearDuplicateTemplateThreshold，返回非空 warning
+// 提示调用方该模板可能是冗余的近重复项；模板本身仍会被注册。
+func RegisterSystemPromptTemplate(template string) (warning string) {
+	return defaultMatcher.AddTemplate(template)
+}
+
+// RegisterSystemPromptTemplateWithThreshold 与 RegisterSystemPromptTemplate 行为一致，但允许
+// 为该模板单独指定匹配阈值，而不是使用调用方传给 IsRealClaudeCodeRequest 等函数的全局阈值。
+func RegisterSystemPromptTemplateWithThreshold(template string, threshold float64) (warning string) {
+	return defaultMatcher.AddTemplateWithThreshold(template, threshold)
+}
+
+// RegisterSystemPromptTemplateStrict 与 RegisterSystemPromptTemplate 行为一致，但在新模板
+// 与已有模板近重复时拒绝注册并返回错误，而非仅给出警告。
+func RegisterSystemPromptTemplateStrict(template string) error {
+	return defaultMatcher.AddTemplateStrict(template)
+}
+
+// SetMaxScoredTemplates 配置每次相似度评分参与比较的最大模板数量；n<=0 时恢复默认值
+// defaultMaxScoredTemplates。
+func SetMaxScoredTemplates(n int) {
+	defaultMatcher.SetMaxScored(n)
+}
+
+// normalizeText 归一化文本用于相似度比较：转小写并折叠连续空白
+func normalizeText(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Join(strings.Fields(s), " ")
+	return s
+}
+
+// stringSimilarity 计算两个字符串的 Dice 系数（基于 bigram）。按 rune 而非字节切分，
+// 避免多字节字符（中日韩文字、emoji 等）被从字符中间切开，产生残缺、无意义的 bigram。
+func stringSimilarity(a, b string) float64 {
+	return normalizedStringSimilarity(normalizeText(a), normalizeText(b))
+}
+
+// normalizedStringSimilarity 与 stringSimilarity 行为一致，但假定 a、b 均已经过
+// normalizeText 处理，供已持有归一化文本的调用方（如 TemplateMatcher 的
+// normalizedScoringTemplatesLocked）跳过重复的归一化开销。
+func normalizedStringSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	aRunes := []rune(a)
+	bRunes := []rune(b)
+	if len(aRunes) < 2 || len(bRunes) < 2 {
+		return 0
+	}
+
+	bigrams := func(runes []rune) map[string]int {
+		counts := make(map[string]int, len(runes))
+		for i := 0; i+2 <= len(runes); i++ {
+			counts[string(runes[i:i+2])]++
+		}
+		return counts
+	}
+
+	aCounts := bigrams(aRunes)
+	bCounts := bigrams(bRunes)
+
+	var matches int
+	for gram, aCount := range aCounts {
+		if bCount, ok := bCounts[gram]; ok {
+			if aCount < bCount {
+				matches += aCount
+			} else {
+				matches += bCount
+			}
+		}
+	}
+
+	total := (len(aRunes) - 1) + (len(bRunes) - 1)
+	if total == 0 {
+		return 0
+	}
+	return 2 * float64(matches) / float64(total)
+}
+
+// wordSet 将文本归一化后按空白切词，返回去重的词集合
+func wordSet(s string) map[string]struct{} {
+	words := strings.Fields(normalizeText(s))
+	set := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		set[w] = struct{}{}
+	}
+	return set
+}
+
+// wordJaccardSimilarity 计算两个字符串的词级 Jaccard 相似度（交集大小 / 并集大小）。
+// 与基于字符 bigram 的 stringSimilarity 相比，词序调整、标点差异对结果影响更小，
+// 适合作为 Dice 系数临界未过阈值时的二次确认信号。
+func wordJaccardSimilarity(a, b string) float64 {
+	aSet := wordSet(a)
+	bSet := wordSet(b)
+	if len(aSet) == 0 && len(bSet) == 0 {
+		return 1
+	}
+	if len(aSet) == 0 || len(bSet) == 0 {
+		return 0
+	}
+
+	var intersection int
+	for w := range aSet {
+		if _, ok := bSet[w]; ok {
+			intersection++
+		}
+	}
+	union := len(aSet) + len(bSet) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// extractSystemText 从 system 字段（字符串或内容块数组）中提取纯文本。数组形式对应
+// Anthropic 的结构化 system 内容块（如 `{type:"text", text:"...", cache_control:{...}}`），
+// 真实 Claude Code 请求有时会把 system 前言拆成两个 text 块（例如中间插入一个带
+// cache_control 的缓存断点），因此这里会跳过非 text 类型的块（type 缺失或不是 "text"），
+// 并把其余全部 text 块按原始顺序拼接后再参与相似度评分，而不是只看第一个块或遇到
+// 不含文本的块就中断。
+func extractSystemText(system any) string {
+	switch v := system.(type) {
+	case string:
+		if text, ok := extractDoubleEncodedSystemText(v); ok {
+			return text
+		}
+		return v
+	case []any:
+		var texts []string
+		for _, part := range v {
+			partMap, ok := part.(map[string]any)
+			if !ok {
+				continue
+			}
+			if blockType, ok := partMap["type"].(string); ok && blockType != "text" {
+				continue
+			}
+			if text, ok := partMap["text"].(string); ok {
+				texts = append(texts, text)
+			}
+		}
+		return strings.Join(texts, " ")
+	case map[string]any:
+		if text, ok := v["text"].(string); ok {
+			return text
+		}
+	}
+	return ""
+}
+
+// extractDoubleEncodedSystemText 处理部分反代二次编码 system 字段的情况：本应是内容块数组
+// 的 system 被整体序列化成了 JSON 字符串（如 `"[{\"type\":\"text\",\"text\":\"...\"}]"`）。
+// 仅当字符串整体能解析为合法的 JSON 数组或对象时才按结构化内容重新提取文本，避免把恰好以
+// `[` 或 `{` 开头的普通提示文本误判为二次编码的 JSON。
+func extractDoubleEncodedSystemText(s string) (string, bool) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return "", false
+	}
+	if trimmed[0] != '[' && trimmed[0] != '{' {
+		return "", false
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(trimmed), &parsed); err != nil {
+		return "", false
+	}
+	switch parsed.(type) {
+	case []any, map[string]any:
+	default:
+		return "", false
+	}
+
+	text := extractSystemText(parsed)
+	if text == "" {
+		return "", false
+	}
+	return text, true
+}
+
+// BestSimilarityByTemplates 返回 system 内容与已知模板中最高的相似度分数
+func BestSimilarityByTemplates(system any) float64 {
+	return defaultMatcher.BestSimilarity(system)
+}
+
+// IsRealClaudeCodeRequest 判断请求的 system 提示是否与已知的 Claude Code 模板足够相似
+func IsRealClaudeCodeRequest(system any, threshold float64) bool {
+	return defaultMatcher.IsRealClaudeCodeRequest(system, threshold)
+}
+
+// BestSimilarityDetail 与 IsRealClaudeCodeRequest 行为一致，但额外返回取得最高相似度的
+// 模板原文及其分数，供调试为什么某个请求未被判定为真实 Claude Code。
+func BestSimilarityDetail(system any, threshold float64) (matched bool, score float64, template string) {
+	return defaultMatcher.BestSimilarityDetail(system, threshold)
+}
+
+// TemplateCoverage 统计一批 system 输入分别最匹配到哪个内置模板，用于评估模板集合是否覆盖了
+// 真实流量：返回结果中缺席的模板即为从未命中；命中次数集中在 templateCoverageNoneKey 桶则
+// 说明真实流量正从模板集合的缝隙中漏出去。
+func TemplateCoverage(systems []interface{}) map[string]int {
+	return defaultMatcher.Coverage(systems, DefaultSystemPromptThreshold)
+}
+
+// BestWordJaccardByTemplates 返回 system 内容与已知模板中最高的词级 Jaccard 相似度
+func BestWordJaccardByTemplates(system any) float64 {
+	text := extractSystemText(system)
+	if text == "" {
+		return 0
+	}
+
+	var best float64
+	for _, tpl := range defaultMatcher.scoringSnapshot() {
+		if score := wordJaccardSimilarity(text, tpl); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// IsRealClaudeCodeRequestWithFallback 是 IsRealClaudeCodeRequest 的两阶段版本，默认关闭
+// （margin<=0 时行为与单阶段 Dice 检测完全一致）。当 Dice 相似度未达 threshold，但差距
+// 在 margin 以内（即 dice >= threshold-margin）时，再用词级 Jaccard 相似度做二次确认，
+// 达到 jaccardThreshold 即判定为真实客户端，用于减少提示词略有改写导致的误判。
+func IsRealClaudeCodeRequestWithFallback(system any, threshold, margin, jaccardThreshold float64) bool {
+	dice := BestSimilarityByTemplates(system)
+	if dice >= threshold {
+		return true
+	}
+	if margin <= 0 || dice < threshold-margin {
+		return false
+	}
+	return BestWordJaccardByTemplates(system) >= jaccardThreshold
+}
+
+// maxUnescapeIterations 限制反转义的迭代层数，防止恶意构造的深层嵌套转义
+// 导致无限循环或过度的字符串分配
+const maxUnescapeIterations = 3
+
+// maxUnescapeInputSize 限制参与反转义的文本长度，超出该长度的输入直接跳过反转义、
+// 按原文参与相似度比较
+const maxUnescapeInputSize = 64 * 1024
+
+// unescapeSystemText 尝试反转义被 JSON 字符串字面量包裹的 system 文本：部分客户端会把
+// 系统提示整体 JSON-stringify 后再嵌入字段，导致原始文本里混入了转义的引号、换行等字符，
+// 稀释了与模板的相似度分数。仅当文本整体形如带双引号的 JSON 字符串字面量时才反转义，
+// 最多反转义 maxUnescapeIterations 层；无法反转义或反转义后文本不再变化时立即停止。
+func unescapeSystemText(s string) string {
+	if s == "" || len(s) > maxUnescapeInputSize {
+		return s
+	}
+
+	current := s
+	for i := 0; i < maxUnescapeIterations; i++ {
+		trimmed := strings.TrimSpace(current)
+		if len(trimmed) < 2 || trimmed[0] != '"' || trimmed[len(trimmed)-1] != '"' {
+			break
+		}
+
+		var unquoted string
+		if err := json.Unmarshal([]byte(trimmed), &unquoted); err != nil {
+			break
+		}
+		if unquoted == current {
+			break
+		}
+		current = unquoted
+	}
+	return current
+}
+
+// BestSimilarityByTemplatesWithUnescape 与 BestSimilarityByTemplates 行为一致，但 unescape 为 true
+// 时先对提取出的 system 文本做 unescapeSystemText 预处理，再与已知模板比较相似度。用于兼容将
+// 系统提示整体 JSON-stringify 后再传输的客户端；unescape 为 false 时行为与原函数完全一致。
+func BestSimilarityByTemplatesWithUnescape(system any, unescape bool) float64 {
+	return BestSimilarityByTemplatesWithOptions(system, unescape, false)
+}
+
+// markdownSyntaxStripper 移除常见的行内 Markdown 语法字符（反引号、星号、下划线、井号、
+// 波浪线），保留其包裹的文本内容本身。不同版本的 Claude Code 客户端偶尔会调整系统提示中
+// 强调/代码高亮的 Markdown 记号，这类纯格式差异不应拉低与模板的相似度分数。
+// 仅做字符级剔除，不解析 Markdown 语法结构（如要求成对匹配），足以覆盖系统提示中
+// 常见的强调、代码块场景。
+var markdownSyntaxStripper = strings.NewReplacer(
+	"`", "",
+	"*", "",
+	"_", "",
+	"#", "",
+	"~", "",
+)
+
+// stripMarkdownSyntax 对 s 应用 markdownSyntaxStripper。
+func stripMarkdownSyntax(s string) string {
+	return markdownSyntaxStripper.Replace(s)
+}
+
+// BestSimilarityByTemplatesWithOptions 与 BestSimilarityByTemplates 行为一致，但可分别控制两个
+// 默认关闭的可选归一化步骤：unescape 为 true 时先做 JSON 反转义预处理；stripMarkdown 为 true
+// 时对提取出的文本及参与比较的模板都剥离 Markdown 语法字符后再评分。两者均为 false 时
+// 与 BestSimilarityByTemplates 完全一致。
+func BestSimilarityByTemplatesWithOptions(system any, unescape, stripMarkdown bool) float64 {
+	text := extractSystemText(system)
+	if text == "" {
+		return 0
+	}
+	if unescape {
+		text = unescapeSystemText(text)
+	}
+	if stripMarkdown {
+		text = stripMarkdownSyntax(text)
+	}
+
+	var best float64
+	for _, tpl := range defaultMatcher.scoringSnapshot() {
+		if stripMarkdown {
+			tpl = stripMarkdownSyntax(tpl)
+		}
+		if score := stringSimilarity(text, tpl); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// IsRealClaudeCodeRequestWithUnescape 是 IsRealClaudeCodeRequest 的变体，unescape 为 true 时
+// 先对 system 文本做反转义预处理再比较相似度，toggled 由调用方依据配置传入。
+func IsRealClaudeCodeRequestWithUnescape(system any, threshold float64, unescape bool) bool {
+	return BestSimilarityByTemplatesWithUnescape(system, unescape) >= threshold
+}
+
+// Grade 是检测结果的三档判定，用于监控面板按健康度分类统计
+type Grade int
+
+const (
+	GradeNotGenuine Grade = iota
+	GradeSuspicious
+	GradeGenuine
+)
+
+// String 返回 Grade 的可读名称
+func (g Grade) String() string {
+	switch g {
+	case GradeGenuine:
+		return "genuine"
+	case GradeSuspicious:
+		return "suspicious"
+	default:
+		return "not-genuine"
+	}
+}
+
+// suspiciousBandRatio 定义可疑区间的下界相对于 threshold 的比例：
+// 相似度落在 [threshold*suspiciousBandRatio, threshold) 之间时判定为可疑而非直接判定为伪造
+const suspiciousBandRatio = 0.6
+
+// GradeForSimilarity 根据相似度分数与阈值将请求归入 genuine/suspicious/not-genuine 三档
+func GradeForSimilarity(similarity, threshold float64) Grade {
+	if similarity >= threshold {
+		return GradeGenuine
+	}
+	if similarity >= threshold*suspiciousBandRatio {
+		return GradeSuspicious
+	}
+	return GradeNotGenuine
+}
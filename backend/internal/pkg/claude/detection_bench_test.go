@@ -0,0 +1,12 @@
+package claude
+
+import "testing"
+
+const benchSystemPrompt = "You are Claude Code, Anthropic's official CLI for Claude."
+
+func BenchmarkBestSimilarityByTemplates(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		BestSimilarityByTemplates(benchSystemPrompt)
+	}
+}
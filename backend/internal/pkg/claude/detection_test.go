@@ -0,0 +1,240 @@
+package claude
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRealClaudeCodeRequest_StandardPrompt(t *testing.T) {
+	assert.True(t, IsRealClaudeCodeRequest("You are Claude Code, Anthropic's official CLI for Claude.", DefaultSystemPromptThreshold))
+}
+
+func TestIsRealClaudeCodeRequest_ThinkingModePlaceholder(t *testing.T) {
+	// 思考模式的真实文案尚未确认，占位模板确保一旦客户端切换到该变体，
+	// 请求仍会被识别为真实 Claude Code 而非被误判。
+	assert.True(t, IsRealClaudeCodeRequest(thinkingModeSystemPromptPlaceholder, DefaultSystemPromptThreshold))
+}
+
+func TestIsRealClaudeCodeRequest_Unrelated(t *testing.T) {
+	assert.False(t, IsRealClaudeCodeRequest("Hello, I am a generic assistant.", DefaultSystemPromptThreshold))
+}
+
+func TestRegisterSystemPromptTemplate(t *testing.T) {
+	before := len(defaultMatcher.Templates())
+	RegisterSystemPromptTemplate("A brand new client variant prompt for testing purposes only.")
+	assert.Len(t, defaultMatcher.Templates(), before+1)
+	assert.True(t, IsRealClaudeCodeRequest("A brand new client variant prompt for testing purposes only.", DefaultSystemPromptThreshold))
+}
+
+func TestRegisterSystemPromptTemplate_WarnsOnNearDuplicate(t *testing.T) {
+	before := len(defaultMatcher.Templates())
+	warning := RegisterSystemPromptTemplate("You are Claude Code, Anthropic's  official  CLI for Claude!")
+	assert.NotEmpty(t, warning, "near-duplicate of the built-in template should produce a warning")
+	assert.Len(t, defaultMatcher.Templates(), before+1, "template is still registered despite the warning")
+}
+
+func TestRegisterSystemPromptTemplate_NoWarningForDistinctTemplate(t *testing.T) {
+	warning := RegisterSystemPromptTemplate("A completely unrelated client fingerprint used only in this test.")
+	assert.Empty(t, warning)
+}
+
+func TestRegisterSystemPromptTemplateStrict_RejectsNearDuplicate(t *testing.T) {
+	before := len(defaultMatcher.Templates())
+	err := RegisterSystemPromptTemplateStrict("You are Claude Code, Anthropic's  official  CLI for Claude!!")
+	assert.Error(t, err)
+	assert.Len(t, defaultMatcher.Templates(), before, "rejected template must not be appended")
+}
+
+func TestRegisterSystemPromptTemplateStrict_AcceptsDistinctTemplate(t *testing.T) {
+	before := len(defaultMatcher.Templates())
+	err := RegisterSystemPromptTemplateStrict("Yet another distinct client variant prompt used only in this test.")
+	assert.NoError(t, err)
+	assert.Len(t, defaultMatcher.Templates(), before+1)
+}
+
+func TestScoringTemplates_TruncatesOversizedTemplateSet(t *testing.T) {
+	original := defaultMatcher.Templates()
+	originalMax := defaultMatcher.maxScored
+	t.Cleanup(func() {
+		defaultMatcher.SetTemplates(original)
+		defaultMatcher.SetMaxScored(originalMax)
+	})
+
+	SetMaxScoredTemplates(3)
+	templates := make([]string, 10)
+	for i := range templates {
+		templates[i] = "template"
+	}
+	defaultMatcher.SetTemplates(templates)
+
+	got := defaultMatcher.scoringSnapshot()
+	assert.Len(t, got, 3, "scoringSnapshot should truncate to the configured cap")
+}
+
+func TestScoringTemplates_NoTruncationUnderCap(t *testing.T) {
+	original := defaultMatcher.Templates()
+	originalMax := defaultMatcher.maxScored
+	t.Cleanup(func() {
+		defaultMatcher.SetTemplates(original)
+		defaultMatcher.SetMaxScored(originalMax)
+	})
+
+	SetMaxScoredTemplates(50)
+	defaultMatcher.SetTemplates([]string{"a", "b", "c"})
+
+	got := defaultMatcher.scoringSnapshot()
+	assert.Len(t, got, 3)
+}
+
+func TestSetMaxScoredTemplates_NonPositiveRestoresDefault(t *testing.T) {
+	originalMax := defaultMatcher.maxScored
+	t.Cleanup(func() { defaultMatcher.SetMaxScored(originalMax) })
+
+	SetMaxScoredTemplates(5)
+	SetMaxScoredTemplates(0)
+
+	assert.Equal(t, defaultMaxScoredTemplates, defaultMatcher.maxScored)
+}
+
+func TestBestSimilarityDetail_ReportsMatchedTemplate(t *testing.T) {
+	matched, score, template := BestSimilarityDetail("You are Claude Code, Anthropic's official CLI for Claude.", DefaultSystemPromptThreshold)
+
+	assert.True(t, matched)
+	assert.Equal(t, 1.0, score)
+	assert.Equal(t, "You are Claude Code, Anthropic's official CLI for Claude.", template)
+}
+
+func TestBestSimilarityDetail_UnrelatedPromptNotMatched(t *testing.T) {
+	matched, score, template := BestSimilarityDetail("Hello, I am a generic assistant.", DefaultSystemPromptThreshold)
+
+	assert.False(t, matched)
+	assert.Less(t, score, DefaultSystemPromptThreshold)
+	assert.NotEmpty(t, template, "still reports the closest template even though it falls short of threshold")
+}
+
+func TestIsRealClaudeCodeRequest_SkipsCacheControlBlockWithoutText(t *testing.T) {
+	system := []any{
+		map[string]any{"type": "text", "text": "", "cache_control": map[string]any{"type": "ephemeral"}},
+		map[string]any{"type": "text", "text": "You are Claude Code, Anthropic's official CLI for Claude."},
+	}
+	assert.True(t, IsRealClaudeCodeRequest(system, DefaultSystemPromptThreshold))
+}
+
+func TestIsRealClaudeCodeRequest_ConcatenatesMultipleTextBlocks(t *testing.T) {
+	system := []any{
+		map[string]any{"type": "text", "text": "You are Claude Code,"},
+		map[string]any{"type": "text", "text": "Anthropic's official CLI for Claude."},
+	}
+	assert.True(t, IsRealClaudeCodeRequest(system, DefaultSystemPromptThreshold))
+}
+
+func TestIsRealClaudeCodeRequest_SkipsNonTextBlockType(t *testing.T) {
+	system := []any{
+		map[string]any{"type": "image", "text": "should be ignored"},
+		map[string]any{"type": "text", "text": "You are Claude Code, Anthropic's official CLI for Claude."},
+	}
+	assert.True(t, IsRealClaudeCodeRequest(system, DefaultSystemPromptThreshold))
+}
+
+func TestIsRealClaudeCodeRequest_DoubleEncodedJSONString(t *testing.T) {
+	doubleEncoded := `[{"type":"text","text":"You are Claude Code, Anthropic's official CLI for Claude."}]`
+	assert.True(t, IsRealClaudeCodeRequest(doubleEncoded, DefaultSystemPromptThreshold))
+}
+
+func TestIsRealClaudeCodeRequest_NormalStringStartingWithBracketIsNotMisparsed(t *testing.T) {
+	// 普通提示文本恰好以 "[" 开头，但整体不是合法 JSON，不应被当作二次编码内容解析。
+	text := "[IMPORTANT] You are a generic assistant, please help the user."
+	assert.False(t, IsRealClaudeCodeRequest(text, DefaultSystemPromptThreshold))
+}
+
+func TestIsRealClaudeCodeRequest_JSONArrayOfPlainStringsIsNotMisparsed(t *testing.T) {
+	// 合法 JSON 但元素不是内容块对象（没有 "text" 字段），提取结果应为空文本，不应误判为真实客户端。
+	text := `["Hello, I am a generic assistant."]`
+	assert.False(t, IsRealClaudeCodeRequest(text, DefaultSystemPromptThreshold))
+}
+
+// asUnicodeEscapedJSONString 将文本编码为全 \uXXXX 转义的 JSON 字符串字面量，用于模拟部分
+// 客户端对 system 内容做的激进转义：结果在原始字符层面与明文相似度极低，但仍是合法 JSON。
+func asUnicodeEscapedJSONString(s string) string {
+	const hexDigits = "0123456789abcdef"
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		sb.WriteString("\\u")
+		v := uint16(r)
+		for i := 3; i >= 0; i-- {
+			sb.WriteByte(hexDigits[(v>>(4*uint(i)))&0xf])
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func TestIsRealClaudeCodeRequestWithUnescape_MatchesAfterUnescaping(t *testing.T) {
+	escaped := asUnicodeEscapedJSONString("You are Claude Code, Anthropic's official CLI for Claude.")
+
+	assert.False(t, IsRealClaudeCodeRequest(escaped, DefaultSystemPromptThreshold),
+		"escaped text should not match templates without unescaping")
+	assert.True(t, IsRealClaudeCodeRequestWithUnescape(escaped, DefaultSystemPromptThreshold, true),
+		"escaped text should match templates once unescaped")
+}
+
+func TestIsRealClaudeCodeRequestWithUnescape_TogglesOff(t *testing.T) {
+	escaped := asUnicodeEscapedJSONString("You are Claude Code, Anthropic's official CLI for Claude.")
+	assert.False(t, IsRealClaudeCodeRequestWithUnescape(escaped, DefaultSystemPromptThreshold, false))
+}
+
+func TestUnescapeSystemText_StopsAtIterationLimit(t *testing.T) {
+	// 构造超过 maxUnescapeIterations 层的嵌套转义，反转义应在层数用尽后停止，
+	// 不会无限循环或 panic。
+	inner := "plain text"
+	nested := inner
+	for i := 0; i < maxUnescapeIterations+2; i++ {
+		encoded, err := json.Marshal(nested)
+		assert.NoError(t, err)
+		nested = string(encoded)
+	}
+	result := unescapeSystemText(nested)
+	assert.NotEqual(t, inner, result)
+}
+
+func TestUnescapeSystemText_OversizedInputIsUntouched(t *testing.T) {
+	huge := `"` + strings.Repeat("a", maxUnescapeInputSize+10) + `"`
+	assert.Equal(t, huge, unescapeSystemText(huge))
+}
+
+func TestBestSimilarityByTemplatesWithOptions_MarkdownStrippingImprovesSimilarity(t *testing.T) {
+	markdownPrompt := "You are **Claude Code**, `Anthropic's` official CLI for Claude."
+
+	withoutStripping := BestSimilarityByTemplatesWithOptions(markdownPrompt, false, false)
+	withStripping := BestSimilarityByTemplatesWithOptions(markdownPrompt, false, true)
+
+	assert.Greater(t, withStripping, withoutStripping)
+	assert.Equal(t, 1.0, withStripping)
+}
+
+func TestStringSimilarity_MultibyteCharactersProduceWellFormedBigrams(t *testing.T) {
+	// 两个字符串仅在一个 emoji 上不同，其余是完全相同的中文文本；按字节切分 bigram 会
+	// 把多字节字符从中间切开，产生一堆残缺、互不相同的片段，人为拉低相似度。
+	a := "你好世界，这是一个测试提示词。"
+	b := "你好世界，这是一个测试提示词🎉。"
+
+	score := stringSimilarity(a, b)
+	assert.Greater(t, score, 0.85, "rune-aware bigrams should score near-identical multibyte strings highly")
+}
+
+func TestBestSimilarityByTemplates_MatchesAfterRegisteringNewTemplate(t *testing.T) {
+	before := len(defaultMatcher.Templates())
+	RegisterSystemPromptTemplate("A brand new normalized-cache regression template.")
+	assert.Len(t, defaultMatcher.Templates(), before+1)
+
+	assert.Equal(t, 1.0, BestSimilarityByTemplates("A brand new normalized-cache regression template."))
+}
+
+func TestBestSimilarityByTemplatesWithOptions_MarkdownStrippingOffByDefault(t *testing.T) {
+	markdownPrompt := "You are **Claude Code**, `Anthropic's` official CLI for Claude."
+	assert.Equal(t, BestSimilarityByTemplates(markdownPrompt), BestSimilarityByTemplatesWithOptions(markdownPrompt, false, false))
+}
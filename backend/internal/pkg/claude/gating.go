@@ -0,0 +1,35 @@
+package claude
+
+// ModelWhitelist 是绕过 Claude Code 门禁检测的模型/端点集合
+// （例如仅需 fingerprint 而不需要严格身份校验的 count_tokens 端点）
+type ModelWhitelist map[string]struct{}
+
+// NewModelWhitelist 从模型 ID 列表构建 ModelWhitelist
+func NewModelWhitelist(models ...string) ModelWhitelist {
+	wl := make(ModelWhitelist, len(models))
+	for _, m := range models {
+		if m != "" {
+			wl[m] = struct{}{}
+		}
+	}
+	return wl
+}
+
+// Bypasses 判断给定模型是否在白名单内，从而跳过 IsRealClaudeCodeRequest 门禁
+func (wl ModelWhitelist) Bypasses(model string) bool {
+	if wl == nil {
+		return false
+	}
+	_, ok := wl[model]
+	return ok
+}
+
+// ShouldGateRequest 综合白名单与系统提示相似度决定是否需要对请求做严格门禁校验。
+// 白名单命中的模型直接放行（仍会应用 fingerprint，但不做门禁判定）；
+// 否则回退到基于 system 提示相似度的 IsRealClaudeCodeRequest 判定。
+func ShouldGateRequest(model string, wl ModelWhitelist, system any, threshold float64) bool {
+	if wl.Bypasses(model) {
+		return false
+	}
+	return !IsRealClaudeCodeRequest(system, threshold)
+}
@@ -0,0 +1,22 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGateRequest_WhitelistedModelBypassesGate(t *testing.T) {
+	wl := NewModelWhitelist("claude-3-5-haiku-count-tokens")
+	assert.False(t, ShouldGateRequest("claude-3-5-haiku-count-tokens", wl, "not a claude code prompt", DefaultSystemPromptThreshold))
+}
+
+func TestShouldGateRequest_NonWhitelistedModelIsGated(t *testing.T) {
+	wl := NewModelWhitelist("claude-3-5-haiku-count-tokens")
+	assert.True(t, ShouldGateRequest("claude-sonnet-4-5", wl, "not a claude code prompt", DefaultSystemPromptThreshold))
+}
+
+func TestShouldGateRequest_NonWhitelistedGenuinePromptPasses(t *testing.T) {
+	wl := NewModelWhitelist("claude-3-5-haiku-count-tokens")
+	assert.False(t, ShouldGateRequest("claude-sonnet-4-5", wl, "You are Claude Code, Anthropic's official CLI for Claude.", DefaultSystemPromptThreshold))
+}
@@ -0,0 +1,34 @@
+package claude
+
+import "net/http"
+
+// expectedFingerprintHeaders 是真实 Claude Code 客户端通常携带的 header 集合，
+// 用于评估传入请求 header 与该集合的匹配程度
+var expectedFingerprintHeaders = []string{
+	"User-Agent",
+	"X-Stainless-Lang",
+	"X-Stainless-Package-Version",
+	"X-Stainless-OS",
+	"X-Stainless-Arch",
+	"X-Stainless-Runtime",
+	"X-Stainless-Runtime-Version",
+	"X-App",
+}
+
+// HeaderFingerprintScore 评估传入请求头与真实 Claude Code 客户端 header 集合的匹配程度，
+// 返回 0-1 之间的分数（存在且取值非空的 header 占比）。
+// 可作为除 User-Agent 和系统提示相似度之外的第三个检测信号。
+func HeaderFingerprintScore(h http.Header) float64 {
+	if h == nil || len(expectedFingerprintHeaders) == 0 {
+		return 0
+	}
+
+	var present int
+	for _, name := range expectedFingerprintHeaders {
+		if h.Get(name) != "" {
+			present++
+		}
+	}
+
+	return float64(present) / float64(len(expectedFingerprintHeaders))
+}
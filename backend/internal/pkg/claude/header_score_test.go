@@ -0,0 +1,24 @@
+package claude
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderFingerprintScore_GenuineHeaderSetScoresHigh(t *testing.T) {
+	h := http.Header{}
+	for name, value := range DefaultHeaders {
+		h.Set(name, value)
+	}
+
+	assert.Equal(t, 1.0, HeaderFingerprintScore(h))
+}
+
+func TestHeaderFingerprintScore_MinimalHeaderSetScoresLow(t *testing.T) {
+	h := http.Header{}
+	h.Set("User-Agent", "curl/8.0")
+
+	assert.Less(t, HeaderFingerprintScore(h), 0.5)
+}
@@ -0,0 +1,84 @@
+package claude
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// NonRealRequestPolicy 定义请求准备阶段在 RequestDecision.IsRealClaudeCode 为 false 时应
+// 采取的处理方式。不同部署对"system 提示与已知 Claude Code 模板不够相似的请求"容忍度不同：
+// 有的希望直接拒绝，有的希望改写为规范提示后继续处理，有的暂时只想观察、不影响请求。
+type NonRealRequestPolicy int
+
+const (
+	// PolicyPassthrough 不做任何处理，请求原样放行。是零值，即未显式配置时的默认行为，
+	// 与在引入本策略之前"仅记录 Grade、不影响请求"的既有行为保持一致。
+	PolicyPassthrough NonRealRequestPolicy = iota
+	// PolicyReject 拒绝请求，ApplyNonRealRequestPolicy 返回 ErrRequestRejectedByPolicy。
+	PolicyReject
+	// PolicyInject 用 CanonicalSystemPrompt 替换请求体的 system 字段后放行。
+	PolicyInject
+)
+
+// String 实现 fmt.Stringer，便于日志/配置校验信息中打印可读的策略名称。
+func (p NonRealRequestPolicy) String() string {
+	switch p {
+	case PolicyPassthrough:
+		return "passthrough"
+	case PolicyReject:
+		return "reject"
+	case PolicyInject:
+		return "inject"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrRequestRejectedByPolicy 是 ApplyNonRealRequestPolicy 在 PolicyReject 下返回的错误，
+// 调用方应将其映射为对客户端的拒绝响应，而不是当作请求准备失败继续往下走。
+var ErrRequestRejectedByPolicy = errors.New("claude: request rejected, system prompt does not match a known Claude Code template")
+
+// injectableRequestBody 是 InjectCanonicalSystemPrompt 用于改写 body 的最小结构：只声明
+// system 字段，其余字段通过 json.RawMessage 原样保留，避免往返编解码丢失调用方未知的字段。
+type injectableRequestBody struct {
+	System json.RawMessage `json:"system"`
+}
+
+// InjectCanonicalSystemPrompt 将 body 的 system 字段替换为 CanonicalSystemPrompt，其余字段
+// 保持不变。body 不是合法 JSON 对象时返回错误。
+func InjectCanonicalSystemPrompt(body []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	canonical, err := json.Marshal(CanonicalSystemPrompt)
+	if err != nil {
+		return nil, err
+	}
+	raw["system"] = canonical
+
+	return json.Marshal(raw)
+}
+
+// ApplyNonRealRequestPolicy 是请求准备阶段消费 RequestDecision 的入口：decision.IsRealClaudeCode
+// 为 true 时，body 原样返回，不受 policy 影响。为 false 时按 policy 处理：
+//   - PolicyPassthrough：body 原样返回。
+//   - PolicyReject：返回 ErrRequestRejectedByPolicy，body 为 nil。
+//   - PolicyInject：返回 InjectCanonicalSystemPrompt 改写后的 body。
+//
+// policy 取值超出以上范围时按 PolicyPassthrough 处理。
+func ApplyNonRealRequestPolicy(body []byte, decision RequestDecision, policy NonRealRequestPolicy) ([]byte, error) {
+	if decision.IsRealClaudeCode {
+		return body, nil
+	}
+
+	switch policy {
+	case PolicyReject:
+		return nil, ErrRequestRejectedByPolicy
+	case PolicyInject:
+		return InjectCanonicalSystemPrompt(body)
+	default:
+		return body, nil
+	}
+}
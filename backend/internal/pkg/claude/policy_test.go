@@ -0,0 +1,79 @@
+package claude
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyNonRealRequestPolicy_RealRequestIsAlwaysPassedThroughUnchanged(t *testing.T) {
+	body := []byte(`{"system":"Hello, I am a generic assistant.","model":"claude-3"}`)
+	decision := RequestDecision{IsRealClaudeCode: true}
+
+	for _, policy := range []NonRealRequestPolicy{PolicyPassthrough, PolicyReject, PolicyInject} {
+		got, err := ApplyNonRealRequestPolicy(body, decision, policy)
+		require.NoError(t, err, "policy %s", policy)
+		assert.Equal(t, body, got, "policy %s", policy)
+	}
+}
+
+func TestApplyNonRealRequestPolicy_PassthroughLeavesNonRealRequestUnchanged(t *testing.T) {
+	body := []byte(`{"system":"Hello, I am a generic assistant.","model":"claude-3"}`)
+	decision := RequestDecision{IsRealClaudeCode: false}
+
+	got, err := ApplyNonRealRequestPolicy(body, decision, PolicyPassthrough)
+
+	require.NoError(t, err)
+	assert.Equal(t, body, got)
+}
+
+func TestApplyNonRealRequestPolicy_RejectReturnsErrorAndNilBody(t *testing.T) {
+	body := []byte(`{"system":"Hello, I am a generic assistant.","model":"claude-3"}`)
+	decision := RequestDecision{IsRealClaudeCode: false}
+
+	got, err := ApplyNonRealRequestPolicy(body, decision, PolicyReject)
+
+	assert.ErrorIs(t, err, ErrRequestRejectedByPolicy)
+	assert.Nil(t, got)
+}
+
+func TestApplyNonRealRequestPolicy_InjectReplacesSystemPromptWithCanonicalText(t *testing.T) {
+	body := []byte(`{"system":"Hello, I am a generic assistant.","model":"claude-3"}`)
+	decision := RequestDecision{IsRealClaudeCode: false}
+
+	got, err := ApplyNonRealRequestPolicy(body, decision, PolicyInject)
+
+	require.NoError(t, err)
+	var parsed struct {
+		System string `json:"system"`
+		Model  string `json:"model"`
+	}
+	require.NoError(t, json.Unmarshal(got, &parsed))
+	assert.Equal(t, CanonicalSystemPrompt, parsed.System)
+	assert.Equal(t, "claude-3", parsed.Model, "fields other than system must be preserved")
+}
+
+func TestInjectCanonicalSystemPrompt_RejectsInvalidJSON(t *testing.T) {
+	_, err := InjectCanonicalSystemPrompt([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestInjectCanonicalSystemPrompt_AddsSystemFieldWhenAbsent(t *testing.T) {
+	got, err := InjectCanonicalSystemPrompt([]byte(`{"model":"claude-3"}`))
+
+	require.NoError(t, err)
+	var parsed struct {
+		System string `json:"system"`
+	}
+	require.NoError(t, json.Unmarshal(got, &parsed))
+	assert.Equal(t, CanonicalSystemPrompt, parsed.System)
+}
+
+func TestNonRealRequestPolicy_StringReturnsReadableNames(t *testing.T) {
+	assert.Equal(t, "passthrough", PolicyPassthrough.String())
+	assert.Equal(t, "reject", PolicyReject.String())
+	assert.Equal(t, "inject", PolicyInject.String())
+	assert.Equal(t, "unknown", NonRealRequestPolicy(99).String())
+}
@@ -0,0 +1,43 @@
+package claude
+
+import "strings"
+
+// StreamingMatcher 对以分块方式到达的 system 文本做增量相似度评分：请求体以流式方式
+// 转发时无需等待整个 body 缓冲完成即可开始比对已知模板，一旦相似度达到阈值即可提前
+// 判定为真实 Claude Code 客户端，供转发路径提前短路使用。
+type StreamingMatcher struct {
+	threshold float64
+	buf       strings.Builder
+	best      float64
+	matched   bool
+}
+
+// NewStreamingMatcher 创建一个以 threshold 作为提前判定阈值的 StreamingMatcher
+func NewStreamingMatcher(threshold float64) *StreamingMatcher {
+	return &StreamingMatcher{threshold: threshold}
+}
+
+// Write 追加一块新到达的文本并重新评估相似度；实现 io.Writer 以便接入现有的流式管道。
+// 已经达到阈值后不再重新评分，避免后续分块拉低已确认的最佳分数。
+func (m *StreamingMatcher) Write(p []byte) (int, error) {
+	if !m.matched {
+		m.buf.Write(p)
+		if score := BestSimilarityByTemplates(m.buf.String()); score > m.best {
+			m.best = score
+		}
+		if m.best >= m.threshold {
+			m.matched = true
+		}
+	}
+	return len(p), nil
+}
+
+// BestSimilarity 返回目前为止观测到的最高相似度分数
+func (m *StreamingMatcher) BestSimilarity() float64 {
+	return m.best
+}
+
+// Matched 返回累计接收到的文本是否已经达到判定阈值
+func (m *StreamingMatcher) Matched() bool {
+	return m.matched
+}
@@ -0,0 +1,46 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamingMatcher_MatchesAcrossMultipleWrites(t *testing.T) {
+	m := NewStreamingMatcher(DefaultSystemPromptThreshold)
+	prompt := "You are Claude Code, Anthropic's official CLI for Claude."
+
+	// 模拟分块到达：在最后一块写入之前，仅凭前缀不应满足阈值
+	_, err := m.Write([]byte(prompt[:10]))
+	assert.NoError(t, err)
+	assert.False(t, m.Matched(), "a short prefix should not yet match")
+
+	_, err = m.Write([]byte(prompt[10:30]))
+	assert.NoError(t, err)
+
+	_, err = m.Write([]byte(prompt[30:]))
+	assert.NoError(t, err)
+	assert.True(t, m.Matched(), "full canonical prompt should match once fully received")
+	assert.GreaterOrEqual(t, m.BestSimilarity(), DefaultSystemPromptThreshold)
+}
+
+func TestStreamingMatcher_UnrelatedTextNeverMatches(t *testing.T) {
+	m := NewStreamingMatcher(DefaultSystemPromptThreshold)
+
+	_, _ = m.Write([]byte("Hello, "))
+	_, _ = m.Write([]byte("I am a generic assistant."))
+
+	assert.False(t, m.Matched())
+	assert.Less(t, m.BestSimilarity(), DefaultSystemPromptThreshold)
+}
+
+func TestStreamingMatcher_StopsUpdatingAfterMatch(t *testing.T) {
+	m := NewStreamingMatcher(DefaultSystemPromptThreshold)
+
+	_, _ = m.Write([]byte("You are Claude Code, Anthropic's official CLI for Claude."))
+	assert.True(t, m.Matched())
+	best := m.BestSimilarity()
+
+	_, _ = m.Write([]byte("some unrelated trailing chunk that would otherwise dilute the score"))
+	assert.Equal(t, best, m.BestSimilarity(), "score should not change once matched")
+}
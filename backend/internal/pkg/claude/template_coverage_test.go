@@ -0,0 +1,44 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateMatcher_CoverageBucketsByMatchedTemplate(t *testing.T) {
+	m := NewTemplateMatcher([]string{"Template A", "Template B"})
+
+	corpus := []interface{}{
+		"Template A",
+		"Template A",
+		"Template B",
+		"Something completely unrelated to either template.",
+	}
+
+	coverage := m.Coverage(corpus, DefaultSystemPromptThreshold)
+
+	assert.Equal(t, 2, coverage["Template A"])
+	assert.Equal(t, 1, coverage["Template B"])
+	assert.Equal(t, 1, coverage[templateCoverageNoneKey])
+}
+
+func TestTemplateMatcher_CoverageEmptyCorpusReturnsEmptyMap(t *testing.T) {
+	m := NewTemplateMatcher([]string{"Template A"})
+
+	coverage := m.Coverage(nil, DefaultSystemPromptThreshold)
+
+	assert.Empty(t, coverage)
+}
+
+func TestTemplateCoverage_UsesDefaultMatcherAndThreshold(t *testing.T) {
+	corpus := []interface{}{
+		"You are Claude Code, Anthropic's official CLI for Claude.",
+		"Hello, I am a generic assistant.",
+	}
+
+	coverage := TemplateCoverage(corpus)
+
+	assert.Equal(t, 1, coverage["You are Claude Code, Anthropic's official CLI for Claude."])
+	assert.Equal(t, 1, coverage[templateCoverageNoneKey])
+}
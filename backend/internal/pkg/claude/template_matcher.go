@@ -0,0 +1,294 @@
+package claude
+
+import (
+	"fmt"
+	"log"
+	"sync"
+)
+
+// nearDuplicateTemplateThreshold 是判定两个模板"近重复"的相似度阈值。超过该值的模板
+// 对检测阈值判断贡献相同，徒增模板列表长度，注册时应予以提示。
+const nearDuplicateTemplateThreshold = 0.9
+
+// defaultMaxScoredTemplates 是每次相似度评分默认参与比较的最大模板数量。模板列表可通过
+// AddTemplate 在运行时无限追加，误配置或恶意注册可能让列表膨胀到成百上千条，使得每次
+// 请求的评分开销随模板数量线性增长（每次都是一次全量 O(templates) 遍历）。
+const defaultMaxScoredTemplates = 200
+
+// Template 是 TemplateMatcher 中的一条模板记录，可为该模板单独配置匹配阈值。
+type Template struct {
+	// Text 是模板原文，用于与请求内容做相似度评分。
+	Text string
+	// Threshold 是该模板专属的匹配阈值；为零值时回退到调用方传给 BestSimilarityDetail /
+	// IsRealClaudeCodeRequest 等方法的全局默认阈值（例如 DefaultSystemPromptThreshold）。
+	// 不同模板的独特程度差异很大——"You are an interactive CLI tool that helps users" 这类
+	// 短模板需要更高的阈值防止误判，篇幅更长、措辞更独特的 SDK 模板可以容忍更低的阈值——
+	// 因此不能让所有模板共用同一个全局阈值。
+	Threshold float64
+}
+
+// TemplateMatcher 持有一份独立的系统提示模板列表并据此做相似度评分，用于在运行时
+// （例如从配置中心加载）替换或追加模板，而不必像内置模板列表那样修改代码并重新部署。
+// 模板列表的读写由 mu 保护：AddTemplate/AddTemplateStrict/SetTemplates/SetMaxScored
+// 持写锁，BestSimilarity/IsRealClaudeCodeRequest/Templates 等只读方法持读锁。
+type TemplateMatcher struct {
+	mu        sync.RWMutex
+	templates []Template
+	maxScored int
+
+	// normalizedMu 与 normalizedCache 缓存 templates 各条目 Text 的 normalizeText 结果，
+	// 与 BestSimilarity 的热路径配套，独立于 mu：模板列表本身很少变化，但每次评分都要
+	// 用到归一化文本，拆成单独的锁避免评分路径和模板增删路径互相阻塞。
+	normalizedMu    sync.Mutex
+	normalizedCache []string
+}
+
+// NewTemplateMatcher 创建一个持有给定模板列表的 TemplateMatcher，各模板均使用全局默认
+// 阈值（Threshold 为零值），评分数量上限使用 defaultMaxScoredTemplates，可通过
+// SetMaxScored 调整。需要按模板单独配置阈值时使用 NewTemplateMatcherWithTemplates。
+func NewTemplateMatcher(templates []string) *TemplateMatcher {
+	return NewTemplateMatcherWithTemplates(templatesFromText(templates))
+}
+
+// NewTemplateMatcherWithTemplates 与 NewTemplateMatcher 行为一致，但允许为每个模板
+// 单独指定 Threshold。
+func NewTemplateMatcherWithTemplates(templates []Template) *TemplateMatcher {
+	return &TemplateMatcher{
+		templates: append([]Template(nil), templates...),
+		maxScored: defaultMaxScoredTemplates,
+	}
+}
+
+// templatesFromText 将纯文本模板列表包装为 Threshold 均为零值（即使用全局默认阈值）的
+// Template 列表。
+func templatesFromText(texts []string) []Template {
+	templates := make([]Template, len(texts))
+	for i, text := range texts {
+		templates[i] = Template{Text: text}
+	}
+	return templates
+}
+
+// AddTemplate 注册一个新模板，用于扩展检测覆盖范围（例如新的客户端变体），使用全局
+// 默认阈值。若新模板与已有模板的相似度超过 nearDuplicateTemplateThreshold，返回非空
+// warning 提示调用方该模板可能是冗余的近重复项；模板本身仍会被注册。
+func (m *TemplateMatcher) AddTemplate(template string) (warning string) {
+	return m.AddTemplateWithThreshold(template, 0)
+}
+
+// AddTemplateWithThreshold 与 AddTemplate 行为一致，但允许为该模板单独指定 Threshold；
+// threshold 为零值时该模板回退到全局默认阈值，与 AddTemplate 完全等价。
+func (m *TemplateMatcher) AddTemplateWithThreshold(template string, threshold float64) (warning string) {
+	if template == "" {
+		return ""
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if dup, score := m.nearDuplicateTemplateLocked(template); dup != "" {
+		warning = fmt.Sprintf("template is %.0f%% similar to an existing template and may be redundant", score*100)
+	}
+	m.templates = append(m.templates, Template{Text: template, Threshold: threshold})
+	return warning
+}
+
+// AddTemplateStrict 与 AddTemplate 行为一致，但在新模板与已有模板近重复时拒绝注册
+// 并返回错误，而非仅给出警告。
+func (m *TemplateMatcher) AddTemplateStrict(template string) error {
+	return m.AddTemplateStrictWithThreshold(template, 0)
+}
+
+// AddTemplateStrictWithThreshold 与 AddTemplateWithThreshold 行为一致，但在新模板与
+// 已有模板近重复时拒绝注册并返回错误，而非仅给出警告。
+func (m *TemplateMatcher) AddTemplateStrictWithThreshold(template string, threshold float64) error {
+	if template == "" {
+		return fmt.Errorf("template must not be empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if dup, score := m.nearDuplicateTemplateLocked(template); dup != "" {
+		return fmt.Errorf("template is %.0f%% similar to an existing template, refusing to register in strict mode", score*100)
+	}
+	m.templates = append(m.templates, Template{Text: template, Threshold: threshold})
+	return nil
+}
+
+// SetTemplates 整体替换模板列表，供从配置加载/热更新场景使用，各模板均使用全局默认阈值。
+func (m *TemplateMatcher) SetTemplates(templates []string) {
+	m.SetTemplatesWithThresholds(templatesFromText(templates))
+}
+
+// SetTemplatesWithThresholds 与 SetTemplates 行为一致，但允许为每个模板单独指定 Threshold。
+func (m *TemplateMatcher) SetTemplatesWithThresholds(templates []Template) {
+	m.mu.Lock()
+	m.templates = append([]Template(nil), templates...)
+	m.mu.Unlock()
+
+	m.normalizedMu.Lock()
+	m.normalizedCache = nil
+	m.normalizedMu.Unlock()
+}
+
+// SetMaxScored 配置该 matcher 每次评分参与比较的最大模板数量；n<=0 时恢复默认值
+// defaultMaxScoredTemplates。
+func (m *TemplateMatcher) SetMaxScored(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n <= 0 {
+		m.maxScored = defaultMaxScoredTemplates
+		return
+	}
+	m.maxScored = n
+}
+
+// Templates 返回当前模板列表各条目 Text 的一份拷贝。需要连同 Threshold 一起读取时
+// 使用 TemplatesWithThresholds。
+func (m *TemplateMatcher) Templates() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	texts := make([]string, len(m.templates))
+	for i, tpl := range m.templates {
+		texts[i] = tpl.Text
+	}
+	return texts
+}
+
+// TemplatesWithThresholds 返回当前模板列表的一份拷贝，包含各模板的 Threshold。
+func (m *TemplateMatcher) TemplatesWithThresholds() []Template {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Template(nil), m.templates...)
+}
+
+// nearDuplicateTemplateLocked 返回与 template 相似度超过 nearDuplicateTemplateThreshold 的
+// 已有模板及其相似度分数；不存在这样的模板时返回空字符串。调用方需持有 m.mu 的写锁。
+func (m *TemplateMatcher) nearDuplicateTemplateLocked(template string) (string, float64) {
+	for _, tpl := range m.templates {
+		if score := stringSimilarity(template, tpl.Text); score > nearDuplicateTemplateThreshold {
+			return tpl.Text, score
+		}
+	}
+	return "", 0
+}
+
+// scoringTemplatesLocked 返回参与本次评分的模板切片：超过 maxScored 时截断到上限并记录
+// 一条警告日志，帮助运维发现模板列表异常增长；未超出时原样返回。调用方需持有 m.mu 的
+// 读锁或写锁。
+func (m *TemplateMatcher) scoringTemplatesLocked() []Template {
+	if len(m.templates) <= m.maxScored {
+		return m.templates
+	}
+	log.Printf("Warning: TemplateMatcher has %d templates, exceeding maxScored=%d; truncating for this scoring pass", len(m.templates), m.maxScored)
+	return m.templates[:m.maxScored]
+}
+
+// scoringSnapshot 返回参与本次评分的模板文本（不含 Threshold），自行加锁并返回一份
+// 独立拷贝，供持锁范围之外、只关心模板原文的调用方（如 BestWordJaccardByTemplates）
+// 安全使用。
+func (m *TemplateMatcher) scoringSnapshot() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	locked := m.scoringTemplatesLocked()
+	texts := make([]string, len(locked))
+	for i, tpl := range locked {
+		texts[i] = tpl.Text
+	}
+	return texts
+}
+
+// normalizedScoringTemplatesLocked 返回参与本次评分的模板 Text 对应的归一化文本，增量维护
+// normalizedCache：模板只会通过 AddTemplate/AddTemplateStrict 追加、不会被原地修改，
+// 因此每次评分只需补齐新追加的部分；SetTemplates 整体替换列表后会重置该缓存。
+// 调用方需持有 m.mu 的读锁或写锁。
+func (m *TemplateMatcher) normalizedScoringTemplatesLocked() []string {
+	m.normalizedMu.Lock()
+	if len(m.normalizedCache) > len(m.templates) {
+		m.normalizedCache = m.normalizedCache[:0]
+	}
+	for i := len(m.normalizedCache); i < len(m.templates); i++ {
+		m.normalizedCache = append(m.normalizedCache, normalizeText(m.templates[i].Text))
+	}
+	cached := m.normalizedCache
+	m.normalizedMu.Unlock()
+
+	if len(cached) <= m.maxScored {
+		return cached
+	}
+	return cached[:m.maxScored]
+}
+
+// effectiveThreshold 返回模板参与匹配判定时实际使用的阈值：tpl.Threshold 非零值时使用
+// 该值，否则回退到调用方传入的全局默认阈值 fallback（例如 DefaultSystemPromptThreshold）。
+func effectiveThreshold(tpl Template, fallback float64) float64 {
+	if tpl.Threshold != 0 {
+		return tpl.Threshold
+	}
+	return fallback
+}
+
+// BestSimilarity 返回 system 内容与该 matcher 模板列表中最高的相似度分数。
+func (m *TemplateMatcher) BestSimilarity(system any) float64 {
+	_, score, _ := m.BestSimilarityDetail(system, 0)
+	return score
+}
+
+// BestSimilarityDetail 与 BestSimilarity 行为一致，但额外返回取得最高分的模板原文，以及该
+// 分数是否已经足以判定为匹配；用于调试为什么某个请求未被判定为真实 Claude Code
+// （例如日志打印 "rejected: best score 0.41 against template X"）。text 为空或模板列表为空
+// 时返回 matched=false、score=0、template=""。
+//
+// matched 按模板各自的阈值判定：任意一个模板的分数达到其 effectiveThreshold(tpl, threshold)
+// （模板自身 Threshold 非零时用它，否则回退到 threshold）即视为匹配；score/template 报告
+// 的始终是分数最高的模板，无论该模板是否达到了自己的阈值，便于观察"差多少"。
+// 各模板 Threshold 均为零值时，本方法与只有单一全局阈值时完全等价。
+func (m *TemplateMatcher) BestSimilarityDetail(system any, threshold float64) (matched bool, score float64, template string) {
+	text := extractSystemText(system)
+	if text == "" {
+		return false, 0, ""
+	}
+	normalizedText := normalizeText(text)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	templates := m.scoringTemplatesLocked()
+	normalized := m.normalizedScoringTemplatesLocked()
+	for i, tpl := range normalized {
+		s := normalizedStringSimilarity(normalizedText, tpl)
+		if s > score {
+			score = s
+			template = templates[i].Text
+		}
+		if s >= effectiveThreshold(templates[i], threshold) {
+			matched = true
+		}
+	}
+	return matched, score, template
+}
+
+// IsRealClaudeCodeRequest 判断请求的 system 提示是否与该 matcher 的模板列表足够相似。
+func (m *TemplateMatcher) IsRealClaudeCodeRequest(system any, threshold float64) bool {
+	matched, _, _ := m.BestSimilarityDetail(system, threshold)
+	return matched
+}
+
+// templateCoverageNoneKey 是 Coverage 中未命中任何模板（相似度低于 threshold）的输入所计入的
+// 桶名，选用一个不可能与合法模板文本冲突的前缀，避免真的存在一条同名模板时产生歧义。
+const templateCoverageNoneKey = "(none/below-threshold)"
+
+// Coverage 统计 systems 中每个输入最匹配到哪个模板，返回模板文本到命中次数的映射；相似度低于
+// threshold 的输入计入 templateCoverageNoneKey 桶。用于评估当前模板集合是否覆盖了真实流量，
+// 以及哪些模板实际从未被匹配到（未出现在返回结果中的模板即为从未命中）。
+func (m *TemplateMatcher) Coverage(systems []interface{}, threshold float64) map[string]int {
+	coverage := make(map[string]int)
+	for _, system := range systems {
+		matched, _, template := m.BestSimilarityDetail(system, threshold)
+		if !matched {
+			coverage[templateCoverageNoneKey]++
+			continue
+		}
+		coverage[template]++
+	}
+	return coverage
+}
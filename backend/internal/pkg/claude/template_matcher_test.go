@@ -0,0 +1,130 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateMatcher_BestSimilarityUsesOwnTemplateList(t *testing.T) {
+	m := NewTemplateMatcher([]string{"Zzyzx quokka narwhal instance-only matcher fixture."})
+
+	assert.Equal(t, 1.0, m.BestSimilarity("Zzyzx quokka narwhal instance-only matcher fixture."))
+	assert.Less(t, BestSimilarityByTemplates("Zzyzx quokka narwhal instance-only matcher fixture."), 0.5)
+}
+
+func TestTemplateMatcher_AddTemplateWarnsOnNearDuplicate(t *testing.T) {
+	m := NewTemplateMatcher([]string{"You are Claude Code, Anthropic's official CLI for Claude."})
+
+	warning := m.AddTemplate("You are Claude Code, Anthropic's  official  CLI for Claude!")
+	assert.NotEmpty(t, warning)
+	assert.Len(t, m.Templates(), 2)
+}
+
+func TestTemplateMatcher_AddTemplateStrictRejectsNearDuplicate(t *testing.T) {
+	m := NewTemplateMatcher([]string{"You are Claude Code, Anthropic's official CLI for Claude."})
+
+	err := m.AddTemplateStrict("You are Claude Code, Anthropic's  official  CLI for Claude!!")
+	assert.Error(t, err)
+	assert.Len(t, m.Templates(), 1)
+}
+
+func TestTemplateMatcher_SetTemplatesReplacesList(t *testing.T) {
+	m := NewTemplateMatcher([]string{"old template"})
+
+	m.SetTemplates([]string{"new template one", "new template two"})
+
+	assert.Equal(t, []string{"new template one", "new template two"}, m.Templates())
+	assert.Equal(t, 1.0, m.BestSimilarity("new template one"))
+	assert.Less(t, m.BestSimilarity("old template"), 1.0)
+}
+
+func TestTemplateMatcher_BestSimilarityDetailReportsMatchedTemplate(t *testing.T) {
+	m := NewTemplateMatcher([]string{"You are a custom internal assistant.", "A second, unrelated template."})
+
+	matched, score, template := m.BestSimilarityDetail("You are a custom internal assistant.", DefaultSystemPromptThreshold)
+
+	assert.True(t, matched)
+	assert.Equal(t, 1.0, score)
+	assert.Equal(t, "You are a custom internal assistant.", template)
+}
+
+func TestTemplateMatcher_BestSimilarityDetailBelowThresholdIsNotMatched(t *testing.T) {
+	m := NewTemplateMatcher([]string{"You are a custom internal assistant."})
+
+	matched, score, template := m.BestSimilarityDetail("Hello, I am a generic assistant.", DefaultSystemPromptThreshold)
+
+	assert.False(t, matched)
+	assert.Less(t, score, DefaultSystemPromptThreshold)
+	assert.Equal(t, "You are a custom internal assistant.", template, "still reports the closest template even though it falls short of threshold")
+}
+
+func TestTemplateMatcher_BestSimilarityDetailEmptyTextReturnsZero(t *testing.T) {
+	m := NewTemplateMatcher([]string{"You are a custom internal assistant."})
+
+	matched, score, template := m.BestSimilarityDetail("", DefaultSystemPromptThreshold)
+
+	assert.False(t, matched)
+	assert.Equal(t, 0.0, score)
+	assert.Empty(t, template)
+}
+
+func TestTemplateMatcher_PerTemplateThresholdOverridesGlobalDefault(t *testing.T) {
+	m := NewTemplateMatcherWithTemplates([]Template{
+		{Text: "You are an interactive CLI tool that helps users", Threshold: 0.9},
+	})
+
+	// 与该短模板有一定相似度但达不到它自己 0.9 的阈值：即便低于该值的分数原本会满足
+	// 全局默认阈值 0.5，也不应被判定为匹配。
+	matched, score, _ := m.BestSimilarityDetail("You are an interactive CLI application that helps developers", DefaultSystemPromptThreshold)
+
+	assert.False(t, matched)
+	assert.Greater(t, score, DefaultSystemPromptThreshold)
+}
+
+func TestTemplateMatcher_ZeroThresholdTemplateFallsBackToGlobalDefault(t *testing.T) {
+	m := NewTemplateMatcherWithTemplates([]Template{
+		{Text: "A long SDK-style template with many distinguishing words in it.", Threshold: 0},
+	})
+
+	matched, _, _ := m.BestSimilarityDetail("A long SDK-style template with many distinguishing words present.", DefaultSystemPromptThreshold)
+
+	assert.True(t, matched, "Threshold of zero should fall back to the global default threshold")
+}
+
+func TestTemplateMatcher_AddTemplateWithThresholdIsUsedByBestSimilarityDetail(t *testing.T) {
+	m := NewTemplateMatcher(nil)
+	m.AddTemplateWithThreshold("Short strict template.", 0.95)
+
+	matched, _, _ := m.BestSimilarityDetail("Short loose template.", DefaultSystemPromptThreshold)
+
+	assert.False(t, matched)
+}
+
+func TestTemplateMatcher_TemplatesWithThresholdsReportsConfiguredValues(t *testing.T) {
+	m := NewTemplateMatcherWithTemplates([]Template{
+		{Text: "template one", Threshold: 0.8},
+		{Text: "template two"},
+	})
+
+	got := m.TemplatesWithThresholds()
+
+	assert.Equal(t, []Template{{Text: "template one", Threshold: 0.8}, {Text: "template two"}}, got)
+}
+
+func TestTemplateMatcher_SetTemplatesWithThresholdsReplacesList(t *testing.T) {
+	m := NewTemplateMatcher([]string{"old template"})
+
+	m.SetTemplatesWithThresholds([]Template{{Text: "new template", Threshold: 0.9}})
+
+	assert.Equal(t, []string{"new template"}, m.Templates())
+	matched, _, _ := m.BestSimilarityDetail("a somewhat similar new template", DefaultSystemPromptThreshold)
+	assert.False(t, matched)
+}
+
+func TestTemplateMatcher_IsRealClaudeCodeRequest(t *testing.T) {
+	m := NewTemplateMatcher([]string{"You are a custom internal assistant."})
+
+	assert.True(t, m.IsRealClaudeCodeRequest("You are a custom internal assistant.", DefaultSystemPromptThreshold))
+	assert.False(t, m.IsRealClaudeCodeRequest("Hello, I am a generic assistant.", DefaultSystemPromptThreshold))
+}
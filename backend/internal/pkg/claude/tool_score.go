@@ -0,0 +1,46 @@
+package claude
+
+// expectedBuiltinTools 是真实 Claude Code 客户端内置工具集合中具有代表性的工具名，
+// 用于评估传入请求 tools 字段与该集合的匹配程度
+var expectedBuiltinTools = []string{
+	"Bash",
+	"Read",
+	"Edit",
+	"Write",
+	"Glob",
+	"Grep",
+	"WebFetch",
+	"TodoWrite",
+}
+
+// ToolSetSimilarity 评估传入请求 tools 数组与真实 Claude Code 内置工具集合的匹配程度，
+// 返回 0-1 之间的分数（存在的预期工具名占比）。tools 中每个元素需为携带 "name" 字段的
+// map（body 反序列化 JSON 对象后的自然形态），无法识别的元素直接跳过。
+// 可作为除系统提示相似度和 header 匹配度之外的第四个检测信号。
+func ToolSetSimilarity(tools []interface{}) float64 {
+	if len(expectedBuiltinTools) == 0 || len(tools) == 0 {
+		return 0
+	}
+
+	names := make(map[string]struct{}, len(tools))
+	for _, tool := range tools {
+		obj, ok := tool.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, ok := obj["name"].(string)
+		if !ok || name == "" {
+			continue
+		}
+		names[name] = struct{}{}
+	}
+
+	var present int
+	for _, name := range expectedBuiltinTools {
+		if _, ok := names[name]; ok {
+			present++
+		}
+	}
+
+	return float64(present) / float64(len(expectedBuiltinTools))
+}
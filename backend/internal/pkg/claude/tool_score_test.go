@@ -0,0 +1,29 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolSetSimilarity_GenuineToolSetScoresHigh(t *testing.T) {
+	tools := make([]interface{}, 0, len(expectedBuiltinTools))
+	for _, name := range expectedBuiltinTools {
+		tools = append(tools, map[string]interface{}{"name": name})
+	}
+
+	assert.Equal(t, 1.0, ToolSetSimilarity(tools))
+}
+
+func TestToolSetSimilarity_CustomToolSetScoresLow(t *testing.T) {
+	tools := []interface{}{
+		map[string]interface{}{"name": "search_web"},
+		map[string]interface{}{"name": "send_email"},
+	}
+
+	assert.Less(t, ToolSetSimilarity(tools), 0.5)
+}
+
+func TestToolSetSimilarity_EmptyToolsScoresZero(t *testing.T) {
+	assert.Equal(t, 0.0, ToolSetSimilarity(nil))
+}
@@ -0,0 +1,47 @@
+package claude
+
+import "context"
+
+// Span 表示一次被追踪的操作。实现方需保证 End 可被安全地调用一次。
+type Span interface {
+	// SetAttribute 记录一个与该 span 关联的键值对（如 account_id、genuine、score）。
+	SetAttribute(key string, value any)
+	End()
+}
+
+// Tracer 为检测/身份相关操作创建 span，用于对接外部分布式追踪系统。
+// 定义为接口是为了不让本包直接依赖具体的追踪 SDK（如 OpenTelemetry）；
+// 调用方按需实现该接口并通过 SetTracer 注入。
+type Tracer interface {
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// noopSpan / noopTracer 是默认实现：不产生任何开销，也不要求调用方判空。
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, any) {}
+func (noopSpan) End()                     {}
+
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// NoopTracer 返回一个不做任何事情的 Tracer，用作默认值。
+func NoopTracer() Tracer {
+	return noopTracer{}
+}
+
+// activeTracer 是 ClassifyRequest 等包级函数使用的 Tracer，默认不启用追踪。
+var activeTracer Tracer = noopTracer{}
+
+// SetTracer 设置包级 Tracer，用于在 ClassifyRequest 等无状态函数周围生成 span。
+// 传入 nil 会恢复为默认的 no-op 实现。
+func SetTracer(t Tracer) {
+	if t == nil {
+		activeTracer = noopTracer{}
+		return
+	}
+	activeTracer = t
+}
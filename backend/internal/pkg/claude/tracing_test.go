@@ -0,0 +1,57 @@
+package claude
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSpan records the attributes it receives, for assertions in tests.
+type fakeSpan struct {
+	attributes map[string]any
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value any) {
+	s.attributes[key] = value
+}
+
+func (s *fakeSpan) End() { s.ended = true }
+
+// fakeTracer is a Tracer test double that keeps the last span it started.
+type fakeTracer struct {
+	name string
+	span *fakeSpan
+}
+
+func (t *fakeTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	t.name = name
+	t.span = &fakeSpan{attributes: make(map[string]any)}
+	return ctx, t.span
+}
+
+func TestClassifyRequest_EmitsSpanWithGenuineAndScore(t *testing.T) {
+	tracer := &fakeTracer{}
+	SetTracer(tracer)
+	t.Cleanup(func() { SetTracer(nil) })
+
+	body := []byte(`{"model":"claude-sonnet-4-20250514","system":"You are Claude Code, Anthropic's official CLI for Claude."}`)
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/messages", nil)
+	assert.NoError(t, err)
+
+	decision := ClassifyRequest(req, body)
+
+	assert.Equal(t, "claude.ClassifyRequest", tracer.name)
+	assert.True(t, tracer.span.ended)
+	assert.Equal(t, decision.IsRealClaudeCode, tracer.span.attributes["genuine"])
+	assert.Equal(t, decision.TemplateSimilarity, tracer.span.attributes["score"])
+}
+
+func TestSetTracer_NilRestoresNoop(t *testing.T) {
+	SetTracer(&fakeTracer{})
+	SetTracer(nil)
+
+	assert.Equal(t, noopTracer{}, activeTracer)
+}
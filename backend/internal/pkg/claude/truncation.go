@@ -0,0 +1,56 @@
+package claude
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// IsTruncatedJSON 检测 body 是否像是被截断的 JSON。用于在转发前拦截被代理缓冲区
+// 截断的请求体（截断后 RewriteUserID 会原样放行，把半个 JSON 转发给上游，导致
+// 上游报出令人费解的错误），改为在网关侧直接返回清晰的 400。
+//
+// 判定方式：先尝试标准解析，能解析的视为完整；解析失败时跟踪未闭合的 {}/[] 括号栈以及
+// 是否仍处于字符串字面量内部——这两种情况正是"被从中间截断"的典型特征。多出的右括号
+// 或其他不成对的语法错误（如多余逗号）不算截断，交给下游 JSON 解析器按普通格式错误处理。
+func IsTruncatedJSON(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return false
+	}
+	if json.Valid(trimmed) {
+		return false
+	}
+
+	var depth int
+	inString := false
+	escaped := false
+
+	for _, c := range trimmed {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				// 多出的右括号：格式错误，但不是"截断"
+				return false
+			}
+			depth--
+		}
+	}
+
+	return inString || depth > 0
+}
@@ -0,0 +1,51 @@
+package claude
+
+import "testing"
+
+func TestIsTruncatedJSON_ValidJSONIsNotTruncated(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4-20250514","stream":true,"metadata":{"user_id":"abc"}}`)
+	if IsTruncatedJSON(body) {
+		t.Fatal("valid JSON must not be flagged as truncated")
+	}
+}
+
+func TestIsTruncatedJSON_UnclosedObjectIsTruncated(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4-20250514","stream":true,"metadata":{"user_id":"abc"`)
+	if !IsTruncatedJSON(body) {
+		t.Fatal("body cut off mid-object should be detected as truncated")
+	}
+}
+
+func TestIsTruncatedJSON_UnterminatedStringIsTruncated(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4-20250514","system":"You are Claude Code, Anthropic'`)
+	if !IsTruncatedJSON(body) {
+		t.Fatal("body cut off inside a string literal should be detected as truncated")
+	}
+}
+
+func TestIsTruncatedJSON_UnclosedArrayIsTruncated(t *testing.T) {
+	body := []byte(`{"messages":[{"role":"user","content":"hi"}`)
+	if !IsTruncatedJSON(body) {
+		t.Fatal("body cut off mid-array should be detected as truncated")
+	}
+}
+
+func TestIsTruncatedJSON_NonJSONBodyIsNotTruncated(t *testing.T) {
+	body := []byte("hello world, this is not json")
+	if IsTruncatedJSON(body) {
+		t.Fatal("plain non-JSON text is malformed, not truncated")
+	}
+}
+
+func TestIsTruncatedJSON_EmptyBodyIsNotTruncated(t *testing.T) {
+	if IsTruncatedJSON(nil) {
+		t.Fatal("empty body must not be flagged as truncated")
+	}
+}
+
+func TestIsTruncatedJSON_ExtraClosingBraceIsNotTruncated(t *testing.T) {
+	body := []byte(`{"model":"x"}}`)
+	if IsTruncatedJSON(body) {
+		t.Fatal("an extra closing brace is a syntax error, not a truncation")
+	}
+}
@@ -0,0 +1,49 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// borderlineSystemPrompt is close enough in wording to the canonical template that word-level
+// Jaccard confirms it, but different enough (CLI expanded to "command line interface") that the
+// character-bigram Dice coefficient alone narrowly misses the default threshold.
+const borderlineSystemPrompt = "Claude Code: Anthropic's official command line interface for Claude"
+
+func TestWordJaccardSimilarity_IdenticalWordSets(t *testing.T) {
+	assert.Equal(t, 1.0, wordJaccardSimilarity("Claude Code", "claude code"))
+}
+
+func TestWordJaccardSimilarity_DisjointWordSets(t *testing.T) {
+	assert.Equal(t, 0.0, wordJaccardSimilarity("foo bar", "baz qux"))
+}
+
+func TestIsRealClaudeCodeRequestWithFallback_DiceAloneRejectsBorderlinePrompt(t *testing.T) {
+	dice := BestSimilarityByTemplates(borderlineSystemPrompt)
+	assert.Less(t, dice, 0.75, "test fixture assumes Dice narrowly misses the 0.75 threshold")
+	assert.False(t, IsRealClaudeCodeRequest(borderlineSystemPrompt, 0.75))
+}
+
+func TestIsRealClaudeCodeRequestWithFallback_TwoStageAcceptsBorderlinePrompt(t *testing.T) {
+	accepted := IsRealClaudeCodeRequestWithFallback(borderlineSystemPrompt, 0.75, 0.1, 0.3)
+	assert.True(t, accepted, "word-level Jaccard should confirm a Dice-borderline rewrite of the known template")
+}
+
+func TestIsRealClaudeCodeRequestWithFallback_MarginZeroDisablesFallback(t *testing.T) {
+	// margin<=0 must behave identically to the single-stage Dice check (opt-in, off by default).
+	accepted := IsRealClaudeCodeRequestWithFallback(borderlineSystemPrompt, 0.75, 0, 0.3)
+	assert.False(t, accepted)
+}
+
+func TestIsRealClaudeCodeRequestWithFallback_OutsideMarginStillRejected(t *testing.T) {
+	// A prompt far below threshold-margin must not be rescued by the fallback, even with a
+	// generous Jaccard threshold.
+	accepted := IsRealClaudeCodeRequestWithFallback("Hello, I am a generic assistant.", 0.75, 0.1, 0.0)
+	assert.False(t, accepted)
+}
+
+func TestIsRealClaudeCodeRequestWithFallback_DiceMeetsThresholdShortCircuits(t *testing.T) {
+	accepted := IsRealClaudeCodeRequestWithFallback("You are Claude Code, Anthropic's official CLI for Claude.", 0.5, 0.1, 1.0)
+	assert.True(t, accepted)
+}
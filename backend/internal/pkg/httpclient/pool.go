@@ -18,13 +18,13 @@ package httpclient
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/proxyutil"
 	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
+	"golang.org/x/net/http2"
 )
 
 // Transport 连接池默认配置
@@ -32,6 +32,11 @@ const (
 	defaultMaxIdleConns        = 100              // 最大空闲连接数
 	defaultMaxIdleConnsPerHost = 10               // 每个主机最大空闲连接数
 	defaultIdleConnTimeout     = 90 * time.Second // 空闲连接超时时间（建议小于上游 LB 超时）
+
+	// Anthropic 的 API 走 HTTP/2，长连接上会保持大量并发 stream；这两个默认值用于
+	// 尽快探测出已死但未被 TCP 层感知到的连接（如经过 NAT/LB 静默丢弃的连接）。
+	defaultHTTP2ReadIdleTimeout = 30 * time.Second // 多久没收到帧就发一次 PING 探活
+	defaultHTTP2PingTimeout     = 15 * time.Second // PING 后多久没收到响应就判定连接已死
 )
 
 // Options 定义共享 HTTP 客户端的构建参数
@@ -48,6 +53,12 @@ type Options struct {
 	MaxIdleConns        int // 最大空闲连接总数（默认 100）
 	MaxIdleConnsPerHost int // 每主机最大空闲连接（默认 10）
 	MaxConnsPerHost     int // 每主机最大连接数（默认 0 无限制）
+
+	// 可选的 HTTP/2 调优参数（不设置则使用默认值），用于在高并发多路复用场景下
+	// （如 Anthropic 的流式 API）尽快探测并淘汰已死的连接
+	HTTP2ReadIdleTimeout            time.Duration // PING 探活间隔（默认 30s，<=0 使用默认值）
+	HTTP2PingTimeout                time.Duration // PING 超时时间（默认 15s，<=0 使用默认值）
+	HTTP2StrictMaxConcurrentStreams bool          // 是否将对端的 SETTINGS_MAX_CONCURRENT_STREAMS 作为全局限制而非按连接限制
 }
 
 // sharedClients 存储按配置参数缓存的 http.Client 实例
@@ -117,24 +128,51 @@ func buildTransport(opts Options) (*http.Transport, error) {
 	}
 
 	proxyURL := strings.TrimSpace(opts.ProxyURL)
-	if proxyURL == "" {
-		return transport, nil
-	}
+	if proxyURL != "" {
+		parsed, err := proxyutil.ValidateProxyURL(proxyURL)
+		if err != nil {
+			return nil, err
+		}
 
-	parsed, err := url.Parse(proxyURL)
-	if err != nil {
-		return nil, err
+		if err := proxyutil.ConfigureTransportProxy(transport, parsed); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := proxyutil.ConfigureTransportProxy(transport, parsed); err != nil {
+	if _, err := configureHTTP2(transport, opts); err != nil {
 		return nil, err
 	}
 
 	return transport, nil
 }
 
+// configureHTTP2 显式启用并调优 transport 的 HTTP/2 支持：默认情况下 http.Transport 也会
+// 自动协商 HTTP/2，但无法定制 PING 探活参数。通过 http2.ConfigureTransports 拿到底层
+// *http2.Transport 后按 Options 中的调优参数（或默认值）设置，以便在长连接上尽快发现死连接。
+// 返回配置后的 *http2.Transport，便于测试直接断言其字段而无需触发真实连接。
+func configureHTTP2(transport *http.Transport, opts Options) (*http2.Transport, error) {
+	h2Transport, err := http2.ConfigureTransports(transport)
+	if err != nil {
+		return nil, fmt.Errorf("configure http2 transport: %w", err)
+	}
+
+	h2Transport.ReadIdleTimeout = opts.HTTP2ReadIdleTimeout
+	if h2Transport.ReadIdleTimeout <= 0 {
+		h2Transport.ReadIdleTimeout = defaultHTTP2ReadIdleTimeout
+	}
+
+	h2Transport.PingTimeout = opts.HTTP2PingTimeout
+	if h2Transport.PingTimeout <= 0 {
+		h2Transport.PingTimeout = defaultHTTP2PingTimeout
+	}
+
+	h2Transport.StrictMaxConcurrentStreams = opts.HTTP2StrictMaxConcurrentStreams
+
+	return h2Transport, nil
+}
+
 func buildClientKey(opts Options) string {
-	return fmt.Sprintf("%s|%s|%s|%t|%t|%t|%t|%d|%d|%d",
+	return fmt.Sprintf("%s|%s|%s|%t|%t|%t|%t|%d|%d|%d|%s|%s|%t",
 		strings.TrimSpace(opts.ProxyURL),
 		opts.Timeout.String(),
 		opts.ResponseHeaderTimeout.String(),
@@ -145,6 +183,9 @@ func buildClientKey(opts Options) string {
 		opts.MaxIdleConns,
 		opts.MaxIdleConnsPerHost,
 		opts.MaxConnsPerHost,
+		opts.HTTP2ReadIdleTimeout.String(),
+		opts.HTTP2PingTimeout.String(),
+		opts.HTTP2StrictMaxConcurrentStreams,
 	)
 }
 
@@ -0,0 +1,56 @@
+package httpclient
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConfigureHTTP2_AppliesDefaultSettings(t *testing.T) {
+	h2t, err := configureHTTP2(&http.Transport{}, Options{})
+	if err != nil {
+		t.Fatalf("configureHTTP2 returned error: %v", err)
+	}
+
+	if h2t.ReadIdleTimeout != defaultHTTP2ReadIdleTimeout {
+		t.Errorf("ReadIdleTimeout = %v, want default %v", h2t.ReadIdleTimeout, defaultHTTP2ReadIdleTimeout)
+	}
+	if h2t.PingTimeout != defaultHTTP2PingTimeout {
+		t.Errorf("PingTimeout = %v, want default %v", h2t.PingTimeout, defaultHTTP2PingTimeout)
+	}
+	if h2t.StrictMaxConcurrentStreams {
+		t.Error("StrictMaxConcurrentStreams should default to false")
+	}
+}
+
+func TestConfigureHTTP2_AppliesConfiguredSettings(t *testing.T) {
+	h2t, err := configureHTTP2(&http.Transport{}, Options{
+		HTTP2ReadIdleTimeout:            5 * time.Second,
+		HTTP2PingTimeout:                2 * time.Second,
+		HTTP2StrictMaxConcurrentStreams: true,
+	})
+	if err != nil {
+		t.Fatalf("configureHTTP2 returned error: %v", err)
+	}
+
+	if h2t.ReadIdleTimeout != 5*time.Second {
+		t.Errorf("ReadIdleTimeout = %v, want %v", h2t.ReadIdleTimeout, 5*time.Second)
+	}
+	if h2t.PingTimeout != 2*time.Second {
+		t.Errorf("PingTimeout = %v, want %v", h2t.PingTimeout, 2*time.Second)
+	}
+	if !h2t.StrictMaxConcurrentStreams {
+		t.Error("StrictMaxConcurrentStreams should be true when configured")
+	}
+}
+
+func TestBuildTransport_ConfiguresHTTP2(t *testing.T) {
+	transport, err := buildTransport(Options{})
+	if err != nil {
+		t.Fatalf("buildTransport returned error: %v", err)
+	}
+
+	if _, ok := transport.TLSNextProto["h2"]; !ok {
+		t.Fatal("expected buildTransport to register HTTP/2 support")
+	}
+}
@@ -16,6 +16,40 @@ import (
 	"golang.org/x/net/proxy"
 )
 
+// validProxySchemes 是受支持的代理协议
+var validProxySchemes = map[string]struct{}{
+	"http":    {},
+	"https":   {},
+	"socks5":  {},
+	"socks5h": {},
+}
+
+// ValidateProxyURL 校验并规范化代理 URL，确保协议受支持且包含 host，
+// 供 GetClient 及更新代理选择逻辑在使用前统一校验。
+func ValidateProxyURL(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("proxy URL is empty")
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if _, ok := validProxySchemes[scheme]; !ok {
+		return nil, fmt.Errorf("unsupported proxy scheme: %s", parsed.Scheme)
+	}
+	parsed.Scheme = scheme
+
+	if parsed.Hostname() == "" {
+		return nil, fmt.Errorf("proxy URL is missing host")
+	}
+
+	return parsed, nil
+}
+
 // ConfigureTransportProxy 根据代理 URL 配置 Transport
 //
 // 支持的协议：
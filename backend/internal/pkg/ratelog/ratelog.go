@@ -0,0 +1,61 @@
+// Package ratelog 提供按 key 去重的限流日志包装器，
+// 避免同一事件（如某账号被反复判定为失效）在短时间内刷屏。
+package ratelog
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Logger 对同一 key 的日志在 window 时间内最多输出一次。
+// 零值不可用，须通过 New 创建。
+type Logger struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// New 创建一个 Logger，window 为同一 key 的日志抑制窗口。
+func New(window time.Duration) *Logger {
+	return &Logger{
+		window: window,
+		last:   make(map[string]time.Time),
+	}
+}
+
+// Printf 在 key 首次出现或距上次输出已超过 window 时输出日志，否则静默丢弃。
+func (l *Logger) Printf(key, format string, args ...any) {
+	if !l.allow(key) {
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// allow 判断 key 当前是否应该被输出，并在允许时刷新时间戳。
+func (l *Logger) allow(key string) bool {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if last, ok := l.last[key]; ok && now.Sub(last) < l.window {
+		return false
+	}
+	l.last[key] = now
+	return true
+}
+
+// Sweep 清理距 now 已超过 idleTTL 未被 Printf 触发过的 key，避免 last 随着长期运行、
+// 账号不断变化而无限增长。仍然活跃的 key（idleTTL 内出现过）保持不变。
+func (l *Logger) Sweep(now time.Time, idleTTL time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, last := range l.last {
+		if now.Sub(last) >= idleTTL {
+			delete(l.last, key)
+		}
+	}
+}
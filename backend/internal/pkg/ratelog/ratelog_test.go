@@ -0,0 +1,55 @@
+package ratelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_SuppressesRepeatedKeyWithinWindow(t *testing.T) {
+	logger := New(50 * time.Millisecond)
+
+	var calls int
+	countingPrintf := func(key string) {
+		if logger.allow(key) {
+			calls++
+		}
+	}
+
+	countingPrintf("account:1:disabled")
+	countingPrintf("account:1:disabled")
+	countingPrintf("account:1:disabled")
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestLogger_AllowsAgainAfterWindowElapses(t *testing.T) {
+	logger := New(20 * time.Millisecond)
+
+	assert.True(t, logger.allow("account:1:disabled"))
+	time.Sleep(30 * time.Millisecond)
+	assert.True(t, logger.allow("account:1:disabled"))
+}
+
+func TestLogger_DistinctKeysAreIndependent(t *testing.T) {
+	logger := New(time.Minute)
+
+	assert.True(t, logger.allow("account:1:disabled"))
+	assert.True(t, logger.allow("account:2:disabled"))
+}
+
+func TestLogger_SweepEvictsIdleKeysOnly(t *testing.T) {
+	logger := New(time.Minute)
+	base := time.Now()
+
+	logger.last["account:1:disabled"] = base.Add(-2 * time.Hour)
+	logger.last["account:2:disabled"] = base
+
+	logger.Sweep(base, time.Hour)
+
+	_, stillPresent := logger.last["account:2:disabled"]
+	_, evicted := logger.last["account:1:disabled"]
+	assert.True(t, stillPresent, "recently active key must survive the sweep")
+	assert.False(t, evicted, "idle key beyond the TTL must be evicted")
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/accountfingerprint"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type fingerprintRepository struct {
+	client *ent.Client
+}
+
+// NewFingerprintRepository 创建 service.FingerprintRepository 的数据库实现，
+// 作为 IdentityCache（Redis，带 TTL）的持久兜底层。
+func NewFingerprintRepository(client *ent.Client) service.FingerprintRepository {
+	return &fingerprintRepository{client: client}
+}
+
+func (r *fingerprintRepository) GetFingerprint(ctx context.Context, accountID int64) (*service.Fingerprint, error) {
+	m, err := r.client.AccountFingerprint.Query().Where(accountfingerprint.AccountIDEQ(accountID)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	fp := &service.Fingerprint{
+		SchemaVersion:           m.SchemaVersion,
+		ClientID:                m.ClientID,
+		UserAgent:               m.UserAgent,
+		StainlessLang:           m.StainlessLang,
+		StainlessPackageVersion: m.StainlessPackageVersion,
+		StainlessOS:             m.StainlessOs,
+		StainlessArch:           m.StainlessArch,
+		StainlessRuntime:        m.StainlessRuntime,
+		StainlessRuntimeVersion: m.StainlessRuntimeVersion,
+	}
+	if m.FingerprintCreatedAt != nil {
+		fp.CreatedAt = *m.FingerprintCreatedAt
+	}
+	return fp, nil
+}
+
+func (r *fingerprintRepository) SetFingerprint(ctx context.Context, accountID int64, fp *service.Fingerprint) error {
+	now := time.Now()
+	createdAt := fp.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+
+	return r.client.AccountFingerprint.
+		Create().
+		SetAccountID(accountID).
+		SetSchemaVersion(fp.SchemaVersion).
+		SetClientID(fp.ClientID).
+		SetUserAgent(fp.UserAgent).
+		SetStainlessLang(fp.StainlessLang).
+		SetStainlessPackageVersion(fp.StainlessPackageVersion).
+		SetStainlessOs(fp.StainlessOS).
+		SetStainlessArch(fp.StainlessArch).
+		SetStainlessRuntime(fp.StainlessRuntime).
+		SetStainlessRuntimeVersion(fp.StainlessRuntimeVersion).
+		SetFingerprintCreatedAt(createdAt).
+		SetUpdatedAt(now).
+		OnConflictColumns(accountfingerprint.FieldAccountID).
+		UpdateNewValues().
+		Exec(ctx)
+}
@@ -3,43 +3,366 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/httpclient"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/proxyutil"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 )
 
+// 以下三个哨兵错误统一标记 githubReleaseClient 三个拉取方法（FetchLatestRelease/
+// FetchReleaseByTag/FetchReleases）的失败类别，供调用方用 errors.Is 区分处理，
+// 也便于在聚合日志里按类别过滤，而不必解析各处措辞不一的错误文案。
+var (
+	// ErrGitHubUnreachable 表示请求未能送达 GitHub（网络错误、超时、代理不可用等），
+	// 与 GitHub 返回了非 200 状态码（ErrGitHubStatus）的情况相区分。
+	ErrGitHubUnreachable = errors.New("github: request unreachable")
+	// ErrGitHubStatus 表示请求成功送达但 GitHub 返回了非 200 状态码。
+	ErrGitHubStatus = errors.New("github: unexpected response status")
+	// ErrReleaseDecode 表示响应体不是预期的 release JSON 形状（解码失败或缺少必需字段）。
+	ErrReleaseDecode = errors.New("github: failed to decode release")
+)
+
+// releaseTagPattern 限定 FetchReleaseByTag 接受的 tag 形状：可选的 "v" 前缀、三段版本号，
+// 以及可选的预发布/构建元数据后缀。不允许出现路径分隔符等能改变请求 URL 结构的字符。
+var releaseTagPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.]+)?$`)
+
+// validateReleaseTag 校验 tag 是否符合 releaseTagPattern，防止把任意用户可控字符串
+// 拼进 GitHub API 请求路径（例如包含 "/" 或 ".." 从而访问到非预期的接口）。
+func validateReleaseTag(tag string) error {
+	if !releaseTagPattern.MatchString(tag) {
+		return fmt.Errorf("invalid release tag %q: must match %s", tag, releaseTagPattern.String())
+	}
+	return nil
+}
+
+// defaultProxyLatencyCacheTTL 是代理测速结果的默认缓存时长，避免每次选择更新代理都重新测速
+const defaultProxyLatencyCacheTTL = 5 * time.Minute
+
+// defaultGitHubAPIBaseURL 是未配置 APIBaseURL 时使用的公共 GitHub API 地址
+const defaultGitHubAPIBaseURL = "https://api.github.com"
+
+// latencyEMAAlpha 是延迟指数移动平均（EMA）的新样本权重：值越大，最近一次探测结果
+// 对平均值的影响越大。用于在 fastestProxy 择优时平滑单次探测的抖动，避免网络瞬时波动
+// 导致代理选择在多次检查之间来回跳变。
+const latencyEMAAlpha = 0.3
+
+type latencyCacheEntry struct {
+	latencyMs    int64 // 最近一次探测的原始值，仅用于问题排查
+	avgLatencyMs int64 // 按 latencyEMAAlpha 计算的滚动平均值，fastestProxy 实际比较的是这个值
+	measuredAt   time.Time
+}
+
 type githubReleaseClient struct {
 	proxyRepo service.ProxyRepository
 	// testClient 用于测试时覆盖 HTTP 客户端
 	testClient *http.Client
+
+	// proxyProber 存在时，findUpdateProxy 在有多个候选"更新"代理时按测速结果择优
+	proxyProber     service.ProxyExitInfoProber
+	proxyLatencyTTL time.Duration
+
+	// apiBaseURL 为空时回退到 defaultGitHubAPIBaseURL，可配置为 GitHub Enterprise 的 API 地址
+	apiBaseURL string
+
+	// directOnly 见 WithDirectConnectionOnly，为 true 时 findUpdateProxy 总是直接返回 nil，
+	// 更新检查/下载完全跳过代理查找，即使存在名称匹配的"更新"代理
+	directOnly bool
+
+	latencyMu    sync.Mutex
+	latencyCache map[string]latencyCacheEntry
+
+	warmMu       sync.Mutex
+	warmedClient *http.Client
+
+	// rateLimitMu 保护 lastRateLimitReset/lastRateLimitResetKnown，记录最近一次从 GitHub API
+	// 响应中观测到的 X-RateLimit-Reset，供 service.RateLimitResetProvider 消费
+	rateLimitMu          sync.Mutex
+	lastRateLimitReset   time.Time
+	lastRateLimitResetOK bool
+
+	// cacheTTLMu 保护 lastCacheTTL/lastCacheTTLOK，记录最近一次从 GitHub API 响应的
+	// Cache-Control/Expires 头解析出的建议缓存有效期，供 service.CacheTTLProvider 消费
+	cacheTTLMu     sync.Mutex
+	lastCacheTTL   time.Duration
+	lastCacheTTLOK bool
+
+	// rateLimitStatusMu 保护 lastRateLimitRemaining/lastRateLimitStatusReset，记录最近一次
+	// 从 GitHub API 响应中观测到的 X-RateLimit-Remaining/X-RateLimit-Reset，无论请求是否成功，
+	// 供 RateLimitStatus 上报，让调用方在预算耗尽前主动退避
+	rateLimitStatusMu        sync.Mutex
+	lastRateLimitRemaining   int
+	lastRateLimitStatusReset time.Time
+	lastRateLimitStatusOK    bool
 }
 
-func NewGitHubReleaseClient(proxyRepo service.ProxyRepository) service.GitHubReleaseClient {
-	return &githubReleaseClient{
+// warmUpdateProxyCheckURL 是 WarmUpdateProxy 用于探测代理可达性的目标地址
+const warmUpdateProxyCheckURL = "https://api.github.com"
+
+// GitHubReleaseClientOption 配置 githubReleaseClient 的可选行为
+type GitHubReleaseClientOption func(*githubReleaseClient)
+
+// WithProxyLatencyProber 启用基于测速的更新代理选择：存在多个名称包含"更新"的候选代理时，
+// 使用 prober 测量各自延迟并选择最快、可用的一个；测速结果缓存 cacheTTL 时长（<=0 时使用默认值）。
+func WithProxyLatencyProber(prober service.ProxyExitInfoProber, cacheTTL time.Duration) GitHubReleaseClientOption {
+	return func(c *githubReleaseClient) {
+		c.proxyProber = prober
+		if cacheTTL <= 0 {
+			cacheTTL = defaultProxyLatencyCacheTTL
+		}
+		c.proxyLatencyTTL = cacheTTL
+	}
+}
+
+// WithAPIBaseURL 配置 GitHub API 的基础地址，用于对接 GitHub Enterprise（例如
+// "https://github.company.com/api/v3"）。baseURL 必须是合法的 HTTPS URL，否则该选项被忽略。
+func WithAPIBaseURL(baseURL string) GitHubReleaseClientOption {
+	return func(c *githubReleaseClient) {
+		parsed, err := url.Parse(baseURL)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			log.Printf("[UpdateService] Ignoring invalid GitHub API base URL %q: must be a well-formed HTTPS URL", baseURL)
+			return
+		}
+		c.apiBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithDirectConnectionOnly 强制更新检查/下载始终走直连，跳过 findUpdateProxy 的代理查找，
+// 供代理链路不稳定的环境使用，而不必删除名称匹配"更新"命名约定的代理本身。
+func WithDirectConnectionOnly() GitHubReleaseClientOption {
+	return func(c *githubReleaseClient) {
+		c.directOnly = true
+	}
+}
+
+// baseURL 返回配置的 GitHub API 基础地址，未配置时回退到公共 API 地址
+func (c *githubReleaseClient) baseURL() string {
+	if c.apiBaseURL != "" {
+		return c.apiBaseURL
+	}
+	return defaultGitHubAPIBaseURL
+}
+
+func NewGitHubReleaseClient(proxyRepo service.ProxyRepository, opts ...GitHubReleaseClientOption) service.GitHubReleaseClient {
+	c := &githubReleaseClient{
 		proxyRepo: proxyRepo,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-// findUpdateProxy 查找名称包含"更新"的代理
+// findUpdateProxy 查找名称包含"更新"且 URL 合法的代理。当配置了 proxyProber 且存在
+// 多个候选代理时，按测速结果选择延迟最低的一个；否则回退为返回第一个匹配的代理。
+// directOnly 为 true 时直接返回 nil，跳过代理查找，让调用方总是走直连。
 func (c *githubReleaseClient) findUpdateProxy(ctx context.Context) *service.Proxy {
+	if c.directOnly {
+		return nil
+	}
 	proxies, err := c.proxyRepo.ListActive(ctx)
 	if err != nil {
+		log.Printf("[UpdateService] debug: ListActive failed while looking for an update proxy: %v", err)
+		return nil
+	}
+	if len(proxies) == 0 {
+		log.Printf("[UpdateService] debug: no active proxies exist, update checks will use a direct connection")
 		return nil
 	}
 
+	var candidates []service.Proxy
 	for i := range proxies {
-		if strings.Contains(proxies[i].Name, "更新") {
-			return &proxies[i]
+		if !strings.Contains(proxies[i].Name, "更新") {
+			continue
+		}
+		if _, err := proxyutil.ValidateProxyURL(proxies[i].URL()); err != nil {
+			log.Printf("[UpdateService] Skipping proxy '%s': invalid URL: %v", proxies[i].Name, err)
+			continue
 		}
+		candidates = append(candidates, proxies[i])
 	}
-	return nil
+
+	if len(candidates) == 0 {
+		log.Printf("[UpdateService] debug: %d active proxies exist but none matched the update proxy naming convention ('更新'), update checks will use a direct connection", len(proxies))
+		return nil
+	}
+	if len(candidates) == 1 || c.proxyProber == nil {
+		return &candidates[0]
+	}
+	return c.fastestProxy(ctx, candidates)
+}
+
+// fastestProxy 对候选代理逐一测速，返回延迟最低的一个；测速失败的代理视为延迟无穷大。
+func (c *githubReleaseClient) fastestProxy(ctx context.Context, candidates []service.Proxy) *service.Proxy {
+	best := &candidates[0]
+	bestLatency := c.latencyFor(ctx, candidates[0])
+	for i := 1; i < len(candidates); i++ {
+		latency := c.latencyFor(ctx, candidates[i])
+		if latency < bestLatency {
+			bestLatency = latency
+			best = &candidates[i]
+		}
+	}
+	return best
+}
+
+// latencyFor 返回代理的滚动平均延迟（毫秒），优先使用未过期的缓存结果；每次重新探测都会
+// 按 latencyEMAAlpha 把新样本并入已有的平均值，而不是直接覆盖，用来适应网络状况随时间变化
+// 的同时抑制单次探测抖动的影响。测速出错时该次样本记为一个很大的值，使该代理在择优比较中
+// 排到最后而不会中断选择流程。
+func (c *githubReleaseClient) latencyFor(ctx context.Context, proxy service.Proxy) int64 {
+	url := proxy.URL()
+
+	c.latencyMu.Lock()
+	entry, hasEntry := c.latencyCache[url]
+	if hasEntry && time.Since(entry.measuredAt) < c.proxyLatencyTTL {
+		c.latencyMu.Unlock()
+		return entry.avgLatencyMs
+	}
+	c.latencyMu.Unlock()
+
+	_, latencyMs, err := c.proxyProber.ProbeProxy(ctx, url)
+	if err != nil {
+		log.Printf("[UpdateService] Failed to probe proxy '%s': %v", proxy.Name, err)
+		latencyMs = math.MaxInt64
+	}
+
+	avgLatencyMs := latencyMs
+	if hasEntry && latencyMs != math.MaxInt64 && entry.avgLatencyMs != math.MaxInt64 {
+		avgLatencyMs = int64(latencyEMAAlpha*float64(latencyMs) + (1-latencyEMAAlpha)*float64(entry.avgLatencyMs))
+	}
+
+	c.latencyMu.Lock()
+	if c.latencyCache == nil {
+		c.latencyCache = make(map[string]latencyCacheEntry)
+	}
+	c.latencyCache[url] = latencyCacheEntry{latencyMs: latencyMs, avgLatencyMs: avgLatencyMs, measuredAt: time.Now()}
+	c.latencyMu.Unlock()
+
+	return avgLatencyMs
+}
+
+// recordRateLimitReset 在响应状态码为限速相关（403/429）时，解析 X-RateLimit-Reset 头部
+// （Unix 秒）并缓存，供 LastRateLimitReset 上报；头部缺失或非限速状态码时不做记录。
+func (c *githubReleaseClient) recordRateLimitReset(statusCode int, header http.Header) {
+	if statusCode != http.StatusForbidden && statusCode != http.StatusTooManyRequests {
+		return
+	}
+	raw := header.Get("X-RateLimit-Reset")
+	if raw == "" {
+		return
+	}
+	seconds, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return
+	}
+
+	c.rateLimitMu.Lock()
+	c.lastRateLimitReset = time.Unix(seconds, 0)
+	c.lastRateLimitResetOK = true
+	c.rateLimitMu.Unlock()
+}
+
+// LastRateLimitReset 返回最近一次从 GitHub API 响应中观测到的速率限制重置时间。
+// 实现 service.RateLimitResetProvider，供 UpdateService 的轮询器据此调整下一次检查时机。
+func (c *githubReleaseClient) LastRateLimitReset() (time.Time, bool) {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.lastRateLimitReset, c.lastRateLimitResetOK
+}
+
+// recordRateLimitStatus 解析 X-RateLimit-Remaining/X-RateLimit-Reset 头部并缓存，供
+// RateLimitStatus 上报。与 recordRateLimitReset 不同，这里不区分状态码：GitHub 在成功和
+// 失败的响应中都会带上这两个头，调用方需要在预算耗尽前提前退避，而不是等到收到 403/429。
+func (c *githubReleaseClient) recordRateLimitStatus(header http.Header) {
+	remainingRaw := header.Get("X-RateLimit-Remaining")
+	resetRaw := header.Get("X-RateLimit-Reset")
+	if remainingRaw == "" || resetRaw == "" {
+		return
+	}
+	remaining, err := strconv.Atoi(remainingRaw)
+	if err != nil {
+		return
+	}
+	seconds, err := strconv.ParseInt(resetRaw, 10, 64)
+	if err != nil {
+		return
+	}
+
+	c.rateLimitStatusMu.Lock()
+	c.lastRateLimitRemaining = remaining
+	c.lastRateLimitStatusReset = time.Unix(seconds, 0)
+	c.lastRateLimitStatusOK = true
+	c.rateLimitStatusMu.Unlock()
+}
+
+// RateLimitStatus 返回最近一次从 GitHub API 响应中观测到的剩余请求配额及其重置时间，
+// 供更新调度器在配额耗尽前主动降低轮询频率。尚未观测到任何响应时 remaining 为 -1。
+func (c *githubReleaseClient) RateLimitStatus() (remaining int, reset time.Time) {
+	c.rateLimitStatusMu.Lock()
+	defer c.rateLimitStatusMu.Unlock()
+	if !c.lastRateLimitStatusOK {
+		return -1, time.Time{}
+	}
+	return c.lastRateLimitRemaining, c.lastRateLimitStatusReset
+}
+
+// recordCacheTTL 解析响应的 Cache-Control（max-age）或 Expires 头，得到 GitHub 对该响应
+// 建议的缓存有效期，供 LastCacheTTL 上报。两者都缺失或无法解析时不记录，调用方回退到
+// 自己配置的固定 TTL。
+func (c *githubReleaseClient) recordCacheTTL(header http.Header) {
+	ttl, ok := parseCacheTTLFromHeaders(header)
+	if !ok {
+		return
+	}
+	c.cacheTTLMu.Lock()
+	c.lastCacheTTL = ttl
+	c.lastCacheTTLOK = true
+	c.cacheTTLMu.Unlock()
+}
+
+// parseCacheTTLFromHeaders 优先取 Cache-Control 的 max-age 指令，其次退到 Expires（与当前
+// 时间的差值），都不存在或已过期时返回 ok=false。
+func parseCacheTTLFromHeaders(header http.Header) (time.Duration, bool) {
+	if cc := header.Get("Cache-Control"); cc != "" {
+		for _, directive := range strings.Split(cc, ",") {
+			rest, ok := strings.CutPrefix(strings.TrimSpace(directive), "max-age=")
+			if !ok {
+				continue
+			}
+			if seconds, err := strconv.Atoi(rest); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second, true
+			}
+		}
+	}
+	if exp := header.Get("Expires"); exp != "" {
+		if t, err := http.ParseTime(exp); err == nil {
+			if ttl := time.Until(t); ttl > 0 {
+				return ttl, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// LastCacheTTL 返回最近一次从 GitHub API 响应中观测到的建议缓存有效期。
+// 实现 service.CacheTTLProvider，供 UpdateService 决定写入自身缓存时的 TTL。
+func (c *githubReleaseClient) LastCacheTTL() (time.Duration, bool) {
+	c.cacheTTLMu.Lock()
+	defer c.cacheTTLMu.Unlock()
+	return c.lastCacheTTL, c.lastCacheTTLOK
 }
 
 // getHTTPClient 获取 HTTP 客户端，优先使用更新代理
@@ -49,6 +372,14 @@ func (c *githubReleaseClient) getHTTPClient(ctx context.Context, timeout time.Du
 		return c.testClient
 	}
 
+	// WarmUpdateProxy 已成功探测过代理可达性时，复用其缓存的客户端，避免重复建连
+	c.warmMu.Lock()
+	warmed := c.warmedClient
+	c.warmMu.Unlock()
+	if warmed != nil {
+		return warmed
+	}
+
 	opts := httpclient.Options{
 		Timeout: timeout,
 	}
@@ -67,8 +398,47 @@ func (c *githubReleaseClient) getHTTPClient(ctx context.Context, timeout time.Du
 	return client
 }
 
+// WarmUpdateProxy 在启动时选择更新代理并做一次轻量可达性探测（HEAD 请求），
+// 探测成功后缓存所构建的客户端供后续更新检查复用，避免首次自动更新检查时才发现代理不可用。
+// 未配置更新代理时视为无需预热，返回 nil。
+func (c *githubReleaseClient) WarmUpdateProxy(ctx context.Context) error {
+	proxy := c.findUpdateProxy(ctx)
+	if proxy == nil {
+		log.Printf("[UpdateService] No update proxy configured, skipping warm-up")
+		return nil
+	}
+
+	client := c.testClient
+	if client == nil {
+		var err error
+		client, err = httpclient.GetClient(httpclient.Options{Timeout: 10 * time.Second, ProxyURL: proxy.URL()})
+		if err != nil {
+			log.Printf("[UpdateService] Failed to build client for update proxy '%s': %v", proxy.Name, err)
+			return fmt.Errorf("build update proxy client: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, warmUpdateProxyCheckURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("[UpdateService] Update proxy '%s' is unreachable: %v", proxy.Name, err)
+		return fmt.Errorf("update proxy '%s' unreachable: %w", proxy.Name, err)
+	}
+	_ = resp.Body.Close()
+
+	c.warmMu.Lock()
+	c.warmedClient = client
+	c.warmMu.Unlock()
+
+	log.Printf("[UpdateService] Update proxy '%s' warmed up successfully", proxy.Name)
+	return nil
+}
+
 func (c *githubReleaseClient) FetchLatestRelease(ctx context.Context, repo string) (*service.GitHubRelease, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", c.baseURL(), repo)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -80,64 +450,198 @@ func (c *githubReleaseClient) FetchLatestRelease(ctx context.Context, repo strin
 	httpClient := c.getHTTPClient(ctx, 30*time.Second)
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("fetch latest release for %s: %w: %w", repo, ErrGitHubUnreachable, err)
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.recordRateLimitStatus(resp.Header)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned %d", resp.StatusCode)
+		c.recordRateLimitReset(resp.StatusCode, resp.Header)
+		return nil, fmt.Errorf("fetch latest release for %s: %w: status %d", repo, ErrGitHubStatus, resp.StatusCode)
 	}
+	c.recordCacheTTL(resp.Header)
 
 	var release service.GitHubRelease
 	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("fetch latest release for %s: %w: %w", repo, ErrReleaseDecode, err)
+	}
+	if err := validateRelease(&release, true); err != nil {
+		return nil, fmt.Errorf("fetch latest release for %s: %w: %w", repo, ErrReleaseDecode, err)
 	}
 
 	return &release, nil
 }
 
-func (c *githubReleaseClient) DownloadFile(ctx context.Context, url, dest string, maxSize int64) error {
+// validateRelease 校验解码后的 release 是否具备更新所需的最小字段集合：GitHub 更改响应结构
+// 或镜像返回不同形状的 JSON 时，json.Decode 不会报错，只会把字段解成零值，导致更新流程带着
+// 空 tag/assets 继续执行。requireAssets 为 true 时同时要求至少存在一个可下载的 asset。
+func validateRelease(r *service.GitHubRelease, requireAssets bool) error {
+	if r.TagName == "" {
+		return fmt.Errorf("invalid release: missing tag_name")
+	}
+	if requireAssets && len(r.Assets) == 0 {
+		return fmt.Errorf("invalid release %s: no assets", r.TagName)
+	}
+	return nil
+}
+
+// FetchReleaseByTag 按 tag 精确获取单个 release。tag 先经过 validateReleaseTag 校验，
+// 拒绝任何不符合版本号形状的输入，避免其被拼入请求路径后改变实际访问的 API 端点。
+func (c *githubReleaseClient) FetchReleaseByTag(ctx context.Context, repo, tag string) (*service.GitHubRelease, error) {
+	if err := validateReleaseTag(tag); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/releases/tags/%s", c.baseURL(), repo, tag)
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "Sub2API-Updater")
 
-	downloadClient := c.getHTTPClient(ctx, 10*time.Minute)
-	resp, err := downloadClient.Do(req)
+	httpClient := c.getHTTPClient(ctx, 30*time.Second)
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("fetch release %s@%s: %w: %w", repo, tag, ErrGitHubUnreachable, err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download returned %d", resp.StatusCode)
+		c.recordRateLimitReset(resp.StatusCode, resp.Header)
+		return nil, fmt.Errorf("fetch release %s@%s: %w: status %d", repo, tag, ErrGitHubStatus, resp.StatusCode)
 	}
 
-	// SECURITY: Check Content-Length if available
-	if resp.ContentLength > maxSize {
-		return fmt.Errorf("file too large: %d bytes (max %d)", resp.ContentLength, maxSize)
+	var release service.GitHubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("fetch release %s@%s: %w: %w", repo, tag, ErrReleaseDecode, err)
+	}
+	if err := validateRelease(&release, true); err != nil {
+		return nil, fmt.Errorf("fetch release %s@%s: %w: %w", repo, tag, ErrReleaseDecode, err)
+	}
+
+	return &release, nil
+}
+
+// FetchReleases 拉取 repo 的 release 列表，跟随 Link: rel="next" 分页，最多拉取 opts.MaxPages 页
+func (c *githubReleaseClient) FetchReleases(ctx context.Context, repo string, opts service.FetchReleasesOptions) ([]service.GitHubRelease, error) {
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+
+	httpClient := c.getHTTPClient(ctx, 30*time.Second)
+	nextURL := fmt.Sprintf("%s/repos/%s/releases", c.baseURL(), repo)
+
+	var releases []service.GitHubRelease
+	for page := 0; page < maxPages && nextURL != ""; page++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, nextURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+		req.Header.Set("User-Agent", "Sub2API-Updater")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetch releases for %s (page %d): %w: %w", repo, page, ErrGitHubUnreachable, err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("fetch releases for %s (page %d): %w: status %d", repo, page, ErrGitHubStatus, resp.StatusCode)
+		}
+
+		var pageReleases []service.GitHubRelease
+		err = json.NewDecoder(resp.Body).Decode(&pageReleases)
+		nextURL = nextPageURL(resp.Header.Get("Link"))
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("fetch releases for %s (page %d): %w: %w", repo, page, ErrReleaseDecode, err)
+		}
+
+		releases = append(releases, pageReleases...)
+	}
+
+	return releases, nil
+}
+
+// nextPageURL 从 GitHub 分页响应的 Link header 中提取 rel="next" 的 URL
+func nextPageURL(linkHeader string) string {
+	if linkHeader == "" {
+		return ""
+	}
+	for _, part := range strings.Split(linkHeader, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		urlPart := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(urlPart, "<") || !strings.HasSuffix(urlPart, ">") {
+			continue
+		}
+		for _, seg := range segments[1:] {
+			if strings.TrimSpace(seg) == `rel="next"` {
+				return strings.Trim(urlPart, "<>")
+			}
+		}
 	}
+	return ""
+}
 
+func (c *githubReleaseClient) DownloadFile(ctx context.Context, url, dest string, maxSize int64) error {
 	out, err := os.Create(dest)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = out.Close() }()
 
+	if _, err := c.DownloadTo(ctx, url, out, maxSize); err != nil {
+		_ = os.Remove(dest) // Clean up partial file (best-effort)
+		return err
+	}
+
+	return nil
+}
+
+// DownloadTo 将 url 的内容流式写入 w，保持与 DownloadFile 相同的大小限制与
+// context 取消语义，但不绑定到具体文件，便于边下载边处理（如边下边解压）。
+func (c *githubReleaseClient) DownloadTo(ctx context.Context, url string, w io.Writer, maxSize int64) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	downloadClient := c.getHTTPClient(ctx, 10*time.Minute)
+	resp, err := downloadClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("download returned %d", resp.StatusCode)
+	}
+
+	// SECURITY: Check Content-Length if available
+	if resp.ContentLength > maxSize {
+		return 0, fmt.Errorf("file too large: %d bytes (max %d)", resp.ContentLength, maxSize)
+	}
+
 	// SECURITY: Use LimitReader to enforce max download size even if Content-Length is missing/wrong
 	limited := io.LimitReader(resp.Body, maxSize+1)
-	written, err := io.Copy(out, limited)
+	written, err := io.Copy(w, limited)
 	if err != nil {
-		return err
+		return written, err
 	}
 
 	// Check if we hit the limit (downloaded more than maxSize)
 	if written > maxSize {
-		_ = os.Remove(dest) // Clean up partial file (best-effort)
-		return fmt.Errorf("download exceeded maximum size of %d bytes", maxSize)
+		return written, fmt.Errorf("download exceeded maximum size of %d bytes", maxSize)
 	}
 
-	return nil
+	return written, nil
 }
 
 func (c *githubReleaseClient) FetchChecksumFile(ctx context.Context, url string) ([]byte, error) {
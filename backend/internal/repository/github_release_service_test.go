@@ -3,12 +3,16 @@ package repository
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -163,6 +167,58 @@ func (s *GitHubReleaseServiceSuite) TestDownloadFile_Success() {
 	require.Len(s.T(), b, 100, "downloaded content length mismatch")
 }
 
+func (s *GitHubReleaseServiceSuite) TestDownloadTo_StreamsIntoBuffer() {
+	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(bytes.Repeat([]byte("c"), 50))
+	}))
+
+	client, ok := NewGitHubReleaseClient(s.proxyRepo).(*githubReleaseClient)
+	require.True(s.T(), ok, "type assertion failed")
+	s.client = client
+
+	var buf bytes.Buffer
+	written, err := s.client.DownloadTo(context.Background(), s.srv.URL, &buf, 200)
+	require.NoError(s.T(), err)
+	require.EqualValues(s.T(), 50, written)
+	require.Equal(s.T(), strings.Repeat("c", 50), buf.String())
+}
+
+func (s *GitHubReleaseServiceSuite) TestDownloadTo_StreamsIntoHashingWriter() {
+	content := bytes.Repeat([]byte("d"), 64)
+	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	}))
+
+	client, ok := NewGitHubReleaseClient(s.proxyRepo).(*githubReleaseClient)
+	require.True(s.T(), ok, "type assertion failed")
+	s.client = client
+
+	hasher := sha256.New()
+	written, err := s.client.DownloadTo(context.Background(), s.srv.URL, hasher, 200)
+	require.NoError(s.T(), err)
+	require.EqualValues(s.T(), 64, written)
+
+	want := sha256.Sum256(content)
+	require.Equal(s.T(), want[:], hasher.Sum(nil))
+}
+
+func (s *GitHubReleaseServiceSuite) TestDownloadTo_EnforcesMaxSize() {
+	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(bytes.Repeat([]byte("e"), 100))
+	}))
+
+	client, ok := NewGitHubReleaseClient(s.proxyRepo).(*githubReleaseClient)
+	require.True(s.T(), ok, "type assertion failed")
+	s.client = client
+
+	var buf bytes.Buffer
+	_, err := s.client.DownloadTo(context.Background(), s.srv.URL, &buf, 10)
+	require.Error(s.T(), err)
+}
+
 func (s *GitHubReleaseServiceSuite) TestDownloadFile_404() {
 	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -299,6 +355,104 @@ func (s *GitHubReleaseServiceSuite) TestFetchLatestRelease_Success() {
 	require.Equal(s.T(), "app-linux-amd64.tar.gz", release.Assets[0].Name)
 }
 
+func (s *GitHubReleaseServiceSuite) TestFetchLatestRelease_UsesConfiguredAPIBaseURL() {
+	var gotURL string
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"tag_name": "v1.0.0", "assets": [{"name": "app.tar.gz"}]}`))
+	}, nil)
+
+	client := NewGitHubReleaseClient(s.proxyRepo, WithAPIBaseURL("https://github.company.com/api/v3")).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	release, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "v1.0.0", release.TagName)
+	require.Equal(s.T(), "https://github.company.com/api/v3/repos/test/repo/releases/latest", gotURL)
+}
+
+func (s *GitHubReleaseServiceSuite) TestFetchReleases_UsesConfiguredAPIBaseURL() {
+	var gotURL string
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"tag_name": "v1.0.0"}]`))
+	}, nil)
+
+	client := NewGitHubReleaseClient(s.proxyRepo, WithAPIBaseURL("https://github.company.com/api/v3")).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	releases, err := client.FetchReleases(context.Background(), "test/repo", service.FetchReleasesOptions{MaxPages: 1})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), releases, 1)
+	require.Equal(s.T(), "https://github.company.com/api/v3/repos/test/repo/releases", gotURL)
+}
+
+func TestWithAPIBaseURL_RejectsNonHTTPSURL(t *testing.T) {
+	client := NewGitHubReleaseClient(nil, WithAPIBaseURL("http://github.company.com/api/v3")).(*githubReleaseClient)
+	require.Equal(t, defaultGitHubAPIBaseURL, client.baseURL())
+}
+
+func TestWithAPIBaseURL_AcceptsWellFormedHTTPSURL(t *testing.T) {
+	client := NewGitHubReleaseClient(nil, WithAPIBaseURL("https://github.company.com/api/v3")).(*githubReleaseClient)
+	require.Equal(t, "https://github.company.com/api/v3", client.baseURL())
+}
+
+func (s *GitHubReleaseServiceSuite) TestFetchReleaseByTag_Success() {
+	releaseJSON := `{
+		"tag_name": "v1.2.3",
+		"name": "Release 1.2.3",
+		"assets": [
+			{
+				"name": "app-linux-amd64.tar.gz",
+				"browser_download_url": "https://github.com/test/repo/releases/download/v1.2.3/app-linux-amd64.tar.gz"
+			}
+		]
+	}`
+
+	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(s.T(), "/repos/test/repo/releases/tags/v1.2.3", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(releaseJSON))
+	}))
+
+	s.client = &githubReleaseClient{
+		proxyRepo: s.proxyRepo,
+		testClient: &http.Client{
+			Transport: &testTransport{testServerURL: s.srv.URL},
+		},
+	}
+
+	release, err := s.client.FetchReleaseByTag(context.Background(), "test/repo", "v1.2.3")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "v1.2.3", release.TagName)
+}
+
+func (s *GitHubReleaseServiceSuite) TestFetchReleaseByTag_RejectsTagWithSlash() {
+	client, ok := NewGitHubReleaseClient(s.proxyRepo).(*githubReleaseClient)
+	require.True(s.T(), ok, "type assertion failed")
+	s.client = client
+
+	_, err := s.client.FetchReleaseByTag(context.Background(), "test/repo", "v1.0.0/../admin")
+	require.Error(s.T(), err)
+	require.Contains(s.T(), err.Error(), "invalid release tag")
+}
+
+func TestValidateReleaseTag_AcceptsValidTags(t *testing.T) {
+	for _, tag := range []string{"1.0.0", "v1.0.0", "v2.0.62-beta.1", "v2.0.62+build.5"} {
+		require.NoError(t, validateReleaseTag(tag), "expected %q to be accepted", tag)
+	}
+}
+
+func TestValidateReleaseTag_RejectsTagWithSlash(t *testing.T) {
+	err := validateReleaseTag("v1.0.0/other")
+	require.Error(t, err)
+}
+
 func (s *GitHubReleaseServiceSuite) TestFetchLatestRelease_Non200() {
 	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -316,6 +470,59 @@ func (s *GitHubReleaseServiceSuite) TestFetchLatestRelease_Non200() {
 	require.Contains(s.T(), err.Error(), "404")
 }
 
+func (s *GitHubReleaseServiceSuite) TestFetchLatestRelease_RateLimitedRecordsResetTime() {
+	resetAt := time.Now().Add(45 * time.Minute).Truncate(time.Second)
+	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", resetAt.Unix()))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	s.client = &githubReleaseClient{
+		proxyRepo: s.proxyRepo,
+		testClient: &http.Client{
+			Transport: &testTransport{testServerURL: s.srv.URL},
+		},
+	}
+
+	_, err := s.client.FetchLatestRelease(context.Background(), "test/repo")
+	require.Error(s.T(), err)
+
+	got, ok := s.client.LastRateLimitReset()
+	require.True(s.T(), ok)
+	require.Equal(s.T(), resetAt.Unix(), got.Unix())
+}
+
+func (s *GitHubReleaseServiceSuite) TestLastRateLimitReset_UnknownWhenNeverObserved() {
+	s.client = &githubReleaseClient{proxyRepo: s.proxyRepo}
+
+	_, ok := s.client.LastRateLimitReset()
+	require.False(s.T(), ok)
+}
+
+func TestValidateRelease_RejectsEmptyTagName(t *testing.T) {
+	err := validateRelease(&service.GitHubRelease{Assets: []service.GitHubAsset{{Name: "app.tar.gz"}}}, true)
+	require.Error(t, err)
+}
+
+func TestValidateRelease_RequireAssetsRejectsDecodedButEmptyRelease(t *testing.T) {
+	// Simulates a mirror/shape change: json.Decode succeeds but assets stay zero-valued.
+	err := validateRelease(&service.GitHubRelease{TagName: "v1.0.0"}, true)
+	require.Error(t, err)
+}
+
+func TestValidateRelease_AssetsNotRequiredWhenNotRequested(t *testing.T) {
+	err := validateRelease(&service.GitHubRelease{TagName: "v1.0.0"}, false)
+	require.NoError(t, err)
+}
+
+func TestValidateRelease_AcceptsWellFormedRelease(t *testing.T) {
+	err := validateRelease(&service.GitHubRelease{
+		TagName: "v1.0.0",
+		Assets:  []service.GitHubAsset{{Name: "app.tar.gz"}},
+	}, true)
+	require.NoError(t, err)
+}
+
 func (s *GitHubReleaseServiceSuite) TestFetchLatestRelease_InvalidJSON() {
 	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -368,6 +575,469 @@ func (s *GitHubReleaseServiceSuite) TestFetchChecksumFile_ContextCancel() {
 	require.Error(s.T(), err)
 }
 
+func (s *GitHubReleaseServiceSuite) TestFetchReleases_PaginationFindsNewestOnSecondPage() {
+	page1 := `[{"tag_name":"v1.2.0","prerelease":false},{"tag_name":"v1.1.0","prerelease":false}]`
+	page2 := `[{"tag_name":"v1.3.0","prerelease":false},{"tag_name":"v1.0.0","prerelease":false}]`
+
+	var calls int
+	s.srv = newLocalTestServer(s.T(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls > 1 {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(page2))
+			return
+		}
+		w.Header().Set("Link", `<`+s.srv.URL+`/repos/test/repo/releases>; rel="next"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(page1))
+	}))
+
+	s.client = &githubReleaseClient{
+		proxyRepo: s.proxyRepo,
+		testClient: &http.Client{
+			Transport: &testTransport{testServerURL: s.srv.URL},
+		},
+	}
+
+	releases, err := s.client.FetchReleases(context.Background(), "test/repo", service.FetchReleasesOptions{MaxPages: 2})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), releases, 4)
+
+	newest := service.SelectNewestRelease(releases, false)
+	require.NotNil(s.T(), newest)
+	require.Equal(s.T(), "v1.3.0", newest.TagName)
+}
+
 func TestGitHubReleaseServiceSuite(t *testing.T) {
 	suite.Run(t, new(GitHubReleaseServiceSuite))
 }
+
+// multiProxyRepository is a ProxyRepository stub that returns a fixed set of active proxies.
+type multiProxyRepository struct {
+	mockProxyRepository
+	proxies []service.Proxy
+}
+
+func (m *multiProxyRepository) ListActive(ctx context.Context) ([]service.Proxy, error) {
+	return m.proxies, nil
+}
+
+// fakeProxyProber returns a preconfigured latency (or error) per proxy URL.
+type fakeProxyProber struct {
+	latencyByURL map[string]int64
+	errByURL     map[string]error
+	calls        map[string]int
+}
+
+func newFakeProxyProber() *fakeProxyProber {
+	return &fakeProxyProber{
+		latencyByURL: make(map[string]int64),
+		errByURL:     make(map[string]error),
+		calls:        make(map[string]int),
+	}
+}
+
+func (p *fakeProxyProber) ProbeProxy(ctx context.Context, proxyURL string) (*service.ProxyExitInfo, int64, error) {
+	p.calls[proxyURL]++
+	if err, ok := p.errByURL[proxyURL]; ok {
+		return nil, 0, err
+	}
+	return &service.ProxyExitInfo{}, p.latencyByURL[proxyURL], nil
+}
+
+func TestFindUpdateProxy_SelectsLowestLatencyCandidate(t *testing.T) {
+	fast := service.Proxy{ID: 1, Name: "更新代理-快", Protocol: "http", Host: "fast.example.com", Port: 8080, Status: service.StatusActive}
+	slow := service.Proxy{ID: 2, Name: "更新代理-慢", Protocol: "http", Host: "slow.example.com", Port: 8080, Status: service.StatusActive}
+
+	prober := newFakeProxyProber()
+	prober.latencyByURL[fast.URL()] = 20
+	prober.latencyByURL[slow.URL()] = 200
+
+	client := &githubReleaseClient{
+		proxyRepo:       &multiProxyRepository{proxies: []service.Proxy{slow, fast}},
+		proxyProber:     prober,
+		proxyLatencyTTL: time.Minute,
+	}
+
+	selected := client.findUpdateProxy(context.Background())
+	require.NotNil(t, selected)
+	require.Equal(t, fast.Name, selected.Name)
+}
+
+func TestFindUpdateProxy_ProbeErrorSinksCandidate(t *testing.T) {
+	broken := service.Proxy{ID: 1, Name: "更新代理-故障", Protocol: "http", Host: "broken.example.com", Port: 8080, Status: service.StatusActive}
+	healthy := service.Proxy{ID: 2, Name: "更新代理-正常", Protocol: "http", Host: "healthy.example.com", Port: 8080, Status: service.StatusActive}
+
+	prober := newFakeProxyProber()
+	prober.errByURL[broken.URL()] = fmt.Errorf("connection refused")
+	prober.latencyByURL[healthy.URL()] = 50
+
+	client := &githubReleaseClient{
+		proxyRepo:       &multiProxyRepository{proxies: []service.Proxy{broken, healthy}},
+		proxyProber:     prober,
+		proxyLatencyTTL: time.Minute,
+	}
+
+	selected := client.findUpdateProxy(context.Background())
+	require.NotNil(t, selected)
+	require.Equal(t, healthy.Name, selected.Name)
+}
+
+func TestFindUpdateProxy_CachesLatencyWithinTTL(t *testing.T) {
+	only := service.Proxy{ID: 1, Name: "更新代理-A", Protocol: "http", Host: "a.example.com", Port: 8080, Status: service.StatusActive}
+	other := service.Proxy{ID: 2, Name: "更新代理-B", Protocol: "http", Host: "b.example.com", Port: 8080, Status: service.StatusActive}
+
+	prober := newFakeProxyProber()
+	prober.latencyByURL[only.URL()] = 10
+	prober.latencyByURL[other.URL()] = 500
+
+	client := &githubReleaseClient{
+		proxyRepo:       &multiProxyRepository{proxies: []service.Proxy{only, other}},
+		proxyProber:     prober,
+		proxyLatencyTTL: time.Minute,
+	}
+
+	first := client.findUpdateProxy(context.Background())
+	second := client.findUpdateProxy(context.Background())
+
+	require.NotNil(t, first)
+	require.NotNil(t, second)
+	require.Equal(t, 1, prober.calls[only.URL()], "expected cached latency to avoid re-probing within TTL")
+	require.Equal(t, 1, prober.calls[other.URL()])
+}
+
+// erroringProxyRepository is a ProxyRepository stub whose ListActive always fails.
+type erroringProxyRepository struct {
+	mockProxyRepository
+	err error
+}
+
+func (m *erroringProxyRepository) ListActive(ctx context.Context) ([]service.Proxy, error) {
+	return nil, m.err
+}
+
+func captureLogOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	var buf bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(original) })
+
+	fn()
+	return buf.String()
+}
+
+func TestFindUpdateProxy_LogsWhenListActiveErrors(t *testing.T) {
+	client := &githubReleaseClient{proxyRepo: &erroringProxyRepository{err: fmt.Errorf("db unavailable")}}
+
+	var selected *service.Proxy
+	output := captureLogOutput(t, func() {
+		selected = client.findUpdateProxy(context.Background())
+	})
+
+	require.Nil(t, selected)
+	require.Contains(t, output, "ListActive failed")
+}
+
+func TestFindUpdateProxy_LogsWhenNoActiveProxiesExist(t *testing.T) {
+	client := &githubReleaseClient{proxyRepo: &multiProxyRepository{proxies: nil}}
+
+	var selected *service.Proxy
+	output := captureLogOutput(t, func() {
+		selected = client.findUpdateProxy(context.Background())
+	})
+
+	require.Nil(t, selected)
+	require.Contains(t, output, "no active proxies exist")
+}
+
+func TestFindUpdateProxy_LogsWhenNoProxyMatchesNamingConvention(t *testing.T) {
+	unrelated := service.Proxy{ID: 1, Name: "普通代理", Protocol: "http", Host: "plain.example.com", Port: 8080, Status: service.StatusActive}
+	client := &githubReleaseClient{proxyRepo: &multiProxyRepository{proxies: []service.Proxy{unrelated}}}
+
+	var selected *service.Proxy
+	output := captureLogOutput(t, func() {
+		selected = client.findUpdateProxy(context.Background())
+	})
+
+	require.Nil(t, selected)
+	require.Contains(t, output, "none matched the update proxy naming convention")
+}
+
+func TestLatencyFor_ComputesRollingAverageAcrossProbes(t *testing.T) {
+	prober := newFakeProxyProber()
+	proxy := service.Proxy{ID: 1, Name: "更新代理", Protocol: "http", Host: "avg.example.com", Port: 8080, Status: service.StatusActive}
+
+	client := &githubReleaseClient{
+		proxyProber:     prober,
+		proxyLatencyTTL: time.Minute,
+	}
+
+	prober.latencyByURL[proxy.URL()] = 100
+	first := client.latencyFor(context.Background(), proxy)
+	require.Equal(t, int64(100), first)
+
+	// 手动让缓存条目过期，绕开 TTL 以便触发第二次探测
+	client.latencyMu.Lock()
+	entry := client.latencyCache[proxy.URL()]
+	entry.measuredAt = time.Now().Add(-2 * time.Minute)
+	client.latencyCache[proxy.URL()] = entry
+	client.latencyMu.Unlock()
+
+	prober.latencyByURL[proxy.URL()] = 300
+	second := client.latencyFor(context.Background(), proxy)
+
+	require.Greater(t, second, first)
+	require.Less(t, second, int64(300), "expected rolling average to smooth the new sample rather than replace it outright")
+}
+
+func TestFindUpdateProxy_DirectOnlySkipsProxyLookupEvenWithMatchingProxy(t *testing.T) {
+	proxy := service.Proxy{ID: 1, Name: "更新代理", Protocol: "http", Host: "proxy.example.com", Port: 8080, Status: service.StatusActive}
+
+	client := &githubReleaseClient{
+		proxyRepo:  &multiProxyRepository{proxies: []service.Proxy{proxy}},
+		directOnly: true,
+	}
+
+	selected := client.findUpdateProxy(context.Background())
+	require.Nil(t, selected)
+}
+
+func TestFindUpdateProxy_WithoutProberReturnsFirstCandidate(t *testing.T) {
+	first := service.Proxy{ID: 1, Name: "更新代理-1", Protocol: "http", Host: "one.example.com", Port: 8080, Status: service.StatusActive}
+	second := service.Proxy{ID: 2, Name: "更新代理-2", Protocol: "http", Host: "two.example.com", Port: 8080, Status: service.StatusActive}
+
+	client := &githubReleaseClient{
+		proxyRepo: &multiProxyRepository{proxies: []service.Proxy{first, second}},
+	}
+
+	selected := client.findUpdateProxy(context.Background())
+	require.NotNil(t, selected)
+	require.Equal(t, first.Name, selected.Name)
+}
+
+// errRoundTripper always fails, simulating an unreachable proxy.
+type errRoundTripper struct{}
+
+func (errRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func TestWarmUpdateProxy_ReachableProxySucceedsAndCachesClient(t *testing.T) {
+	proxy := service.Proxy{ID: 1, Name: "更新代理", Protocol: "http", Host: "proxy.example.com", Port: 8080, Status: service.StatusActive}
+	okClient := &http.Client{Transport: http.DefaultTransport}
+
+	client := &githubReleaseClient{
+		proxyRepo:  &multiProxyRepository{proxies: []service.Proxy{proxy}},
+		testClient: okClient,
+	}
+	// Point the reachability check at a local server via testClient's transport rewrite.
+	srv := newLocalTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	client.testClient = &http.Client{Transport: &testTransport{testServerURL: srv.URL}}
+
+	err := client.WarmUpdateProxy(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, client.warmedClient)
+}
+
+func TestWarmUpdateProxy_UnreachableProxyReturnsError(t *testing.T) {
+	proxy := service.Proxy{ID: 1, Name: "更新代理", Protocol: "http", Host: "proxy.example.com", Port: 8080, Status: service.StatusActive}
+
+	client := &githubReleaseClient{
+		proxyRepo:  &multiProxyRepository{proxies: []service.Proxy{proxy}},
+		testClient: &http.Client{Transport: errRoundTripper{}},
+	}
+
+	err := client.WarmUpdateProxy(context.Background())
+	require.Error(t, err)
+	require.Nil(t, client.warmedClient)
+}
+
+func TestWarmUpdateProxy_NoProxyConfiguredIsNoOp(t *testing.T) {
+	client := &githubReleaseClient{
+		proxyRepo: &multiProxyRepository{proxies: []service.Proxy{}},
+	}
+
+	err := client.WarmUpdateProxy(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, client.warmedClient)
+}
+
+func TestFetchLatestRelease_RecordsCacheTTLFromMaxAge(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"tag_name": "v1.0.0", "assets": [{"name": "app.tar.gz"}]}`))
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.NoError(t, err)
+
+	ttl, ok := client.LastCacheTTL()
+	require.True(t, ok)
+	require.Equal(t, 60*time.Second, ttl)
+}
+
+func TestFetchLatestRelease_FallsBackToExpiresHeaderWhenNoCacheControl(t *testing.T) {
+	expiresAt := time.Now().Add(2 * time.Minute)
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Expires", expiresAt.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"tag_name": "v1.0.0", "assets": [{"name": "app.tar.gz"}]}`))
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.NoError(t, err)
+
+	ttl, ok := client.LastCacheTTL()
+	require.True(t, ok)
+	require.InDelta(t, 2*time.Minute, ttl, float64(2*time.Second))
+}
+
+func TestFetchLatestRelease_NoCacheHeadersLeavesCacheTTLUnset(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"tag_name": "v1.0.0", "assets": [{"name": "app.tar.gz"}]}`))
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.NoError(t, err)
+
+	_, ok := client.LastCacheTTL()
+	require.False(t, ok)
+}
+
+func TestRateLimitStatus_ParsesHeadersFromResponse(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"tag_name": "v1.0.0", "assets": [{"name": "app.tar.gz"}]}`))
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.NoError(t, err)
+
+	remaining, reset := client.RateLimitStatus()
+	require.Equal(t, 42, remaining)
+	require.Equal(t, time.Unix(1700000000, 0), reset)
+}
+
+func TestRateLimitStatus_UnknownWhenNeverObserved(t *testing.T) {
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+
+	remaining, reset := client.RateLimitStatus()
+	require.Equal(t, -1, remaining)
+	require.True(t, reset.IsZero())
+}
+
+func TestRateLimitStatus_UpdatedEvenOnNonOKResponse(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.WriteHeader(http.StatusForbidden)
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.Error(t, err)
+
+	remaining, _ := client.RateLimitStatus()
+	require.Equal(t, 0, remaining)
+}
+
+func TestFetchLatestRelease_NetworkFailureWrapsErrGitHubUnreachable(t *testing.T) {
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("connection refused")
+	})}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.ErrorIs(t, err, ErrGitHubUnreachable)
+}
+
+func TestFetchLatestRelease_NonOKStatusWrapsErrGitHubStatus(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.ErrorIs(t, err, ErrGitHubStatus)
+}
+
+func TestFetchLatestRelease_MalformedBodyWrapsErrReleaseDecode(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`not json`))
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.ErrorIs(t, err, ErrReleaseDecode)
+}
+
+func TestFetchLatestRelease_MissingRequiredFieldsWrapsErrReleaseDecode(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"tag_name": ""}`))
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchLatestRelease(context.Background(), "test/repo")
+	require.ErrorIs(t, err, ErrReleaseDecode)
+}
+
+func TestFetchReleaseByTag_NonOKStatusWrapsErrGitHubStatus(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchReleaseByTag(context.Background(), "test/repo", "v1.0.0")
+	require.ErrorIs(t, err, ErrGitHubStatus)
+}
+
+func TestFetchReleases_NonOKStatusWrapsErrGitHubStatus(t *testing.T) {
+	transport := newInProcessTransport(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}, nil)
+
+	client := NewGitHubReleaseClient(nil).(*githubReleaseClient)
+	client.testClient = &http.Client{Transport: transport}
+
+	_, err := client.FetchReleases(context.Background(), "test/repo", service.FetchReleasesOptions{MaxPages: 1})
+	require.ErrorIs(t, err, ErrGitHubStatus)
+}
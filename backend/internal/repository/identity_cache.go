@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -49,3 +51,45 @@ func (c *identityCache) SetFingerprint(ctx context.Context, accountID int64, fp
 	}
 	return c.rdb.Set(ctx, key, val, fingerprintTTL).Err()
 }
+
+// DeleteFingerprint 删除指定账号的缓存指纹。键本不存在时 redis.Client.Del 不报错，符合幂等约定。
+func (c *identityCache) DeleteFingerprint(ctx context.Context, accountID int64) error {
+	return c.rdb.Del(ctx, fingerprintKey(accountID)).Err()
+}
+
+// AllFingerprints 通过 SCAN 遍历所有 fingerprint:* 键，返回 accountID -> Fingerprint。
+// 单个键解析失败时跳过该键并继续，不中断整体遍历。
+func (c *identityCache) AllFingerprints(ctx context.Context) (map[int64]*service.Fingerprint, error) {
+	result := make(map[int64]*service.Fingerprint)
+
+	var cursor uint64
+	for {
+		keys, next, err := c.rdb.Scan(ctx, cursor, fingerprintKeyPrefix+"*", 100).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			accountID, err := strconv.ParseInt(strings.TrimPrefix(key, fingerprintKeyPrefix), 10, 64)
+			if err != nil {
+				continue
+			}
+			val, err := c.rdb.Get(ctx, key).Result()
+			if err != nil {
+				continue
+			}
+			var fp service.Fingerprint
+			if err := json.Unmarshal([]byte(val), &fp); err != nil {
+				continue
+			}
+			result[accountID] = &fp
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -62,6 +62,16 @@ func (s *IdentityCacheSuite) TestSetFingerprint_Nil() {
 	require.NoError(s.T(), err, "SetFingerprint(nil) should succeed")
 }
 
+func (s *IdentityCacheSuite) TestAllFingerprints_ReturnsAllCachedAccounts() {
+	require.NoError(s.T(), s.cache.SetFingerprint(s.ctx, 10, &service.Fingerprint{ClientID: "c10", UserAgent: "ua10"}))
+	require.NoError(s.T(), s.cache.SetFingerprint(s.ctx, 11, &service.Fingerprint{ClientID: "c11", UserAgent: "ua11"}))
+
+	all, err := s.cache.AllFingerprints(s.ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "c10", all[10].ClientID)
+	require.Equal(s.T(), "c11", all[11].ClientID)
+}
+
 func TestIdentityCacheSuite(t *testing.T) {
 	suite.Run(t, new(IdentityCacheSuite))
 }
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+const sessionVerdictKeyPrefix = "session_verdict:"
+
+// sessionVerdictKey generates the Redis key for a session's cached detection verdict.
+func sessionVerdictKey(sessionHash string) string {
+	return sessionVerdictKeyPrefix + sessionHash
+}
+
+type sessionVerdictCache struct {
+	rdb *redis.Client
+}
+
+func NewSessionVerdictCache(rdb *redis.Client) service.SessionVerdictCache {
+	return &sessionVerdictCache{rdb: rdb}
+}
+
+func (c *sessionVerdictCache) GetSessionVerdict(ctx context.Context, sessionHash string) (claude.Grade, bool, error) {
+	val, err := c.rdb.Get(ctx, sessionVerdictKey(sessionHash)).Result()
+	if err == redis.Nil {
+		return claude.GradeNotGenuine, false, nil
+	}
+	if err != nil {
+		return claude.GradeNotGenuine, false, err
+	}
+	grade, err := strconv.Atoi(val)
+	if err != nil {
+		return claude.GradeNotGenuine, false, fmt.Errorf("parse cached session verdict: %w", err)
+	}
+	return claude.Grade(grade), true, nil
+}
+
+func (c *sessionVerdictCache) SetSessionVerdict(ctx context.Context, sessionHash string, grade claude.Grade, ttl time.Duration) error {
+	return c.rdb.Set(ctx, sessionVerdictKey(sessionHash), strconv.Itoa(int(grade)), ttl).Err()
+}
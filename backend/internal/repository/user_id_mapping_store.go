@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+const userIDMappingKeyPrefix = "user_id_mapping:"
+
+// userIDMappingKey generates the Redis key for a rewritten->original user_id mapping.
+func userIDMappingKey(rewrittenUserID string) string {
+	return fmt.Sprintf("%s%s", userIDMappingKeyPrefix, rewrittenUserID)
+}
+
+type userIDMappingStore struct {
+	rdb *redis.Client
+}
+
+// NewUserIDMappingStore creates a Redis-backed service.UserIDMappingStore.
+func NewUserIDMappingStore(rdb *redis.Client) service.UserIDMappingStore {
+	return &userIDMappingStore{rdb: rdb}
+}
+
+func (s *userIDMappingStore) RecordUserIDMapping(ctx context.Context, rewrittenUserID, originalUserID string, ttl time.Duration) error {
+	key := userIDMappingKey(rewrittenUserID)
+	return s.rdb.Set(ctx, key, originalUserID, ttl).Err()
+}
+
+func (s *userIDMappingStore) LookupOriginalUserID(ctx context.Context, rewrittenUserID string) (string, error) {
+	key := userIDMappingKey(rewrittenUserID)
+	val, err := s.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", nil
+		}
+		return "", err
+	}
+	return val, nil
+}
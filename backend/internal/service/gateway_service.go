@@ -1556,6 +1556,33 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 	}, nil
 }
 
+// applyIdentityRewrites 为 OAuth 账号解析/生成指纹，并据此重写请求体中的 metadata.user_id。
+// 返回值在函数返回前已完全就绪：调用方可以立即用返回的 body 构造上游请求，无需等待任何
+// 异步步骤——这一点对流式请求尤其重要，SSE 转发一旦开始就不会再回头改写已发出的 body。
+func (s *GatewayService) applyIdentityRewrites(ctx context.Context, c *gin.Context, account *Account, body []byte) ([]byte, *Fingerprint) {
+	if !account.IsOAuth() || s.identityService == nil {
+		return body, nil
+	}
+
+	// 1. 获取或创建指纹（包含随机生成的ClientID）
+	fp, err := s.identityService.GetOrCreateFingerprint(ctx, account.ID, c.Request.Header)
+	if err != nil {
+		log.Printf("Warning: failed to get fingerprint for account %d: %v", account.ID, err)
+		// 失败时降级为透传原始headers
+		return body, nil
+	}
+
+	// 2. 重写metadata.user_id（需要指纹中的ClientID和账号的account_uuid）
+	accountUUID := account.GetExtraString("account_uuid")
+	if accountUUID != "" && fp.ClientID != "" {
+		if newBody, err := s.identityService.RewriteUserID(body, account.ID, accountUUID, fp.ClientID); err == nil && len(newBody) > 0 {
+			body = newBody
+		}
+	}
+
+	return body, fp
+}
+
 func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Context, account *Account, body []byte, token, tokenType, modelID string) (*http.Request, error) {
 	// 确定目标URL
 	targetURL := claudeAPIURL
@@ -1570,26 +1597,9 @@ func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Contex
 		}
 	}
 
-	// OAuth账号：应用统一指纹
-	var fingerprint *Fingerprint
-	if account.IsOAuth() && s.identityService != nil {
-		// 1. 获取或创建指纹（包含随机生成的ClientID）
-		fp, err := s.identityService.GetOrCreateFingerprint(ctx, account.ID, c.Request.Header)
-		if err != nil {
-			log.Printf("Warning: failed to get fingerprint for account %d: %v", account.ID, err)
-			// 失败时降级为透传原始headers
-		} else {
-			fingerprint = fp
-
-			// 2. 重写metadata.user_id（需要指纹中的ClientID和账号的account_uuid）
-			accountUUID := account.GetExtraString("account_uuid")
-			if accountUUID != "" && fp.ClientID != "" {
-				if newBody, err := s.identityService.RewriteUserID(body, account.ID, accountUUID, fp.ClientID); err == nil && len(newBody) > 0 {
-					body = newBody
-				}
-			}
-		}
-	}
+	// 重写请求体、解析指纹：这些转换必须在构造 http.Request 前同步完成，无论 stream 是否为
+	// true——流式请求同样需要携带重写后的 user_id 与指纹 header，转发阶段不会再回头改写 body。
+	body, fingerprint := s.applyIdentityRewrites(ctx, c, account, body)
 
 	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewReader(body))
 	if err != nil {
@@ -0,0 +1,55 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuildUpstreamRequest_StreamingRequestGetsUserIDRewrittenAndFingerprintApplied 防止流式请求
+// 绕过身份重写：body 转换必须在请求转发前同步完成，stream:true 不应改变这一点。
+func TestBuildUpstreamRequest_StreamingRequestGetsUserIDRewrittenAndFingerprintApplied(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cache := newFakeIdentityCache()
+	svc := &GatewayService{identityService: NewIdentityService(cache)}
+
+	account := &Account{
+		ID:   42,
+		Type: AccountTypeOAuth,
+		Extra: map[string]any{
+			"account_uuid": "11111111-1111-1111-1111-111111111111",
+		},
+	}
+
+	body := []byte(`{"stream":true,"metadata":{"user_id":"user_` +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		`_account__session_22222222-2222-2222-2222-222222222222"}}`)
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Set("User-Agent", "claude-cli/2.0.62 (external, cli)")
+
+	req, err := svc.buildUpstreamRequest(context.Background(), c, account, body, "token", "oauth", "claude-3-5-sonnet")
+	require.NoError(t, err)
+
+	rewritten, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(rewritten), "_account_11111111-1111-1111-1111-111111111111_session_",
+		"streaming request body must still get user_id rewritten before forwarding")
+	require.NotContains(t, string(rewritten), "_account__session_",
+		"original unrewritten user_id must not survive")
+
+	fp, ok := cache.fingerprints[account.ID]
+	require.True(t, ok, "fingerprint must be created/cached for the OAuth account")
+	require.NotEmpty(t, req.Header.Get("x-stainless-lang"), "fingerprint headers must be applied to the upstream request")
+	require.Equal(t, fp.StainlessLang, req.Header.Get("x-stainless-lang"))
+}
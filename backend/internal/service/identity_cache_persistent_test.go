@@ -0,0 +1,93 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFingerprintRepository is an in-memory FingerprintRepository stub for unit tests.
+type fakeFingerprintRepository struct {
+	fingerprints map[int64]*Fingerprint
+}
+
+func newFakeFingerprintRepository() *fakeFingerprintRepository {
+	return &fakeFingerprintRepository{fingerprints: make(map[int64]*Fingerprint)}
+}
+
+func (r *fakeFingerprintRepository) GetFingerprint(_ context.Context, accountID int64) (*Fingerprint, error) {
+	fp, ok := r.fingerprints[accountID]
+	if !ok {
+		return nil, nil
+	}
+	return fp, nil
+}
+
+func (r *fakeFingerprintRepository) SetFingerprint(_ context.Context, accountID int64, fp *Fingerprint) error {
+	r.fingerprints[accountID] = fp
+	return nil
+}
+
+func TestPersistentIdentityCache_FallsBackToRepoOnCacheMiss(t *testing.T) {
+	cache := newFakeIdentityCache()
+	repo := newFakeFingerprintRepository()
+	repo.fingerprints[1] = &Fingerprint{ClientID: "from-db"}
+
+	persistent := NewPersistentIdentityCache(cache, repo)
+
+	fp, err := persistent.GetFingerprint(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, fp)
+	require.Equal(t, "from-db", fp.ClientID)
+
+	cached, ok := cache.fingerprints[1]
+	require.True(t, ok, "cache should be warmed from the repository after a miss")
+	require.Equal(t, "from-db", cached.ClientID)
+}
+
+func TestPersistentIdentityCache_ReturnsNilWhenNeitherLayerHasData(t *testing.T) {
+	persistent := NewPersistentIdentityCache(newFakeIdentityCache(), newFakeFingerprintRepository())
+
+	fp, err := persistent.GetFingerprint(context.Background(), 99)
+	require.NoError(t, err)
+	require.Nil(t, fp)
+}
+
+func TestPersistentIdentityCache_SetFingerprintWritesThroughToRepo(t *testing.T) {
+	cache := newFakeIdentityCache()
+	repo := newFakeFingerprintRepository()
+	persistent := NewPersistentIdentityCache(cache, repo)
+
+	fp := &Fingerprint{ClientID: "written"}
+	require.NoError(t, persistent.SetFingerprint(context.Background(), 2, fp))
+
+	require.Equal(t, "written", cache.fingerprints[2].ClientID)
+	require.Equal(t, "written", repo.fingerprints[2].ClientID)
+}
+
+func TestPersistentIdentityCache_PrefersCacheWhenFresh(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[3] = &Fingerprint{ClientID: "from-cache"}
+	repo := newFakeFingerprintRepository()
+	repo.fingerprints[3] = &Fingerprint{ClientID: "from-db"}
+
+	persistent := NewPersistentIdentityCache(cache, repo)
+
+	fp, err := persistent.GetFingerprint(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, "from-cache", fp.ClientID, "a cache hit must not be overridden by the repository")
+}
+
+func TestPersistentIdentityCache_AllFingerprintsDelegatesToCache(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[4] = &Fingerprint{ClientID: "from-cache"}
+	persistent := NewPersistentIdentityCache(cache, newFakeFingerprintRepository())
+
+	all, err := persistent.AllFingerprints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.Equal(t, "from-cache", all[4].ClientID)
+}
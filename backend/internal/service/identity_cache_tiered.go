@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// TieredIdentityCache 在慢速的 L2 缓存（通常是 Redis）之前叠加一层更快的 L1 缓存：读操作
+// 优先命中新鲜的 L1，未命中/已过期时读 L2 并回填 L1；写操作同时写穿两层。L2 出现错误时
+// 读操作回落到 L1（即使已过期），避免 Redis 抖动导致指纹读取整体不可用。
+type TieredIdentityCache struct {
+	l1    IdentityCache
+	l2    IdentityCache
+	l1TTL time.Duration
+
+	mu       sync.RWMutex
+	cachedAt map[int64]time.Time
+}
+
+// NewTieredIdentityCache 创建一个以 l1 为一级缓存、l2 为二级缓存的 TieredIdentityCache。
+// l1TTL 控制 L1 条目的新鲜期，超过该时长后读操作不再信任 L1、转而回源 L2；l1TTL<=0 表示
+// L1 条目永不因时间过期。
+func NewTieredIdentityCache(l1, l2 IdentityCache, l1TTL time.Duration) *TieredIdentityCache {
+	return &TieredIdentityCache{
+		l1:       l1,
+		l2:       l2,
+		l1TTL:    l1TTL,
+		cachedAt: make(map[int64]time.Time),
+	}
+}
+
+// GetFingerprint 优先从新鲜的 L1 读取；L1 未命中/已过期时读 L2 并回填 L1。L2 读取出错时
+// 退化为直接从 L1 读取（即使已过期），保证 L2 抖动不会让请求彻底失去可用的指纹。
+func (c *TieredIdentityCache) GetFingerprint(ctx context.Context, accountID int64) (*Fingerprint, error) {
+	if c.l1Fresh(accountID) {
+		if fp, err := c.l1.GetFingerprint(ctx, accountID); err == nil && fp != nil {
+			return fp, nil
+		}
+	}
+
+	fp, err := c.l2.GetFingerprint(ctx, accountID)
+	if err != nil {
+		log.Printf("Warning: TieredIdentityCache L2 read failed for account %d, falling back to L1: %v", accountID, err)
+		return c.l1.GetFingerprint(ctx, accountID)
+	}
+	if fp != nil {
+		if err := c.l1.SetFingerprint(ctx, accountID, fp); err != nil {
+			log.Printf("Warning: TieredIdentityCache failed to populate L1 for account %d: %v", accountID, err)
+		} else {
+			c.markCached(accountID)
+		}
+	}
+	return fp, nil
+}
+
+// SetFingerprint 写穿 L1 与 L2。L1 写入失败仅记录警告（下一次读取会回源 L2 重新填充），
+// 但 L2 写入失败会向上返回错误，与非分层的 IdentityCache 实现语义保持一致。
+func (c *TieredIdentityCache) SetFingerprint(ctx context.Context, accountID int64, fp *Fingerprint) error {
+	if err := c.l1.SetFingerprint(ctx, accountID, fp); err != nil {
+		log.Printf("Warning: TieredIdentityCache failed to write L1 for account %d: %v", accountID, err)
+	} else {
+		c.markCached(accountID)
+	}
+	return c.l2.SetFingerprint(ctx, accountID, fp)
+}
+
+// AllFingerprints 直接委托给 L2：L1 只是按账号索引的读缓存，不适合支撑全量枚举语义，
+// 且并非所有 L1 实现都需要支持枚举。
+func (c *TieredIdentityCache) AllFingerprints(ctx context.Context) (map[int64]*Fingerprint, error) {
+	return c.l2.AllFingerprints(ctx)
+}
+
+// DeleteFingerprint 同时从 L1、L2 删除，并清除 L1 的新鲜度标记，避免 L1 因残留的
+// cachedAt 记录而在下次读取时被误判为"新鲜"从而掩盖删除。L1 删除失败仅记录警告
+// （下一次读取会回源 L2，L2 已删除因此读到 nil），但 L2 删除失败会向上返回错误。
+func (c *TieredIdentityCache) DeleteFingerprint(ctx context.Context, accountID int64) error {
+	if err := c.l1.DeleteFingerprint(ctx, accountID); err != nil {
+		log.Printf("Warning: TieredIdentityCache failed to delete L1 entry for account %d: %v", accountID, err)
+	}
+	c.mu.Lock()
+	delete(c.cachedAt, accountID)
+	c.mu.Unlock()
+	return c.l2.DeleteFingerprint(ctx, accountID)
+}
+
+func (c *TieredIdentityCache) l1Fresh(accountID int64) bool {
+	if c.l1TTL <= 0 {
+		return true
+	}
+	c.mu.RLock()
+	cachedAt, ok := c.cachedAt[accountID]
+	c.mu.RUnlock()
+	return ok && time.Since(cachedAt) < c.l1TTL
+}
+
+func (c *TieredIdentityCache) markCached(accountID int64) {
+	c.mu.Lock()
+	c.cachedAt[accountID] = time.Now()
+	c.mu.Unlock()
+}
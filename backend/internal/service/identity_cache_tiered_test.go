@@ -0,0 +1,108 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// erroringL2IdentityCache simulates an L2 (Redis) that is temporarily unavailable.
+type erroringL2IdentityCache struct {
+	*fakeIdentityCache
+	getErr error
+	setErr error
+}
+
+func newErroringL2IdentityCache() *erroringL2IdentityCache {
+	return &erroringL2IdentityCache{fakeIdentityCache: newFakeIdentityCache()}
+}
+
+func (c *erroringL2IdentityCache) GetFingerprint(ctx context.Context, accountID int64) (*Fingerprint, error) {
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	return c.fakeIdentityCache.GetFingerprint(ctx, accountID)
+}
+
+func (c *erroringL2IdentityCache) SetFingerprint(ctx context.Context, accountID int64, fp *Fingerprint) error {
+	if c.setErr != nil {
+		return c.setErr
+	}
+	return c.fakeIdentityCache.SetFingerprint(ctx, accountID, fp)
+}
+
+func TestTieredIdentityCache_ReadThroughPopulatesL1(t *testing.T) {
+	l1 := newFakeIdentityCache()
+	l2 := newFakeIdentityCache()
+	l2.fingerprints[1] = &Fingerprint{ClientID: "from-l2"}
+
+	tiered := NewTieredIdentityCache(l1, l2, time.Minute)
+
+	fp, err := tiered.GetFingerprint(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, fp)
+	require.Equal(t, "from-l2", fp.ClientID)
+
+	l1Fp, ok := l1.fingerprints[1]
+	require.True(t, ok, "L1 should be populated after a read-through from L2")
+	require.Equal(t, "from-l2", l1Fp.ClientID)
+}
+
+func TestTieredIdentityCache_WriteThroughUpdatesBothLayers(t *testing.T) {
+	l1 := newFakeIdentityCache()
+	l2 := newFakeIdentityCache()
+	tiered := NewTieredIdentityCache(l1, l2, time.Minute)
+
+	fp := &Fingerprint{ClientID: "written"}
+	require.NoError(t, tiered.SetFingerprint(context.Background(), 2, fp))
+
+	require.Equal(t, "written", l1.fingerprints[2].ClientID)
+	require.Equal(t, "written", l2.fingerprints[2].ClientID)
+}
+
+func TestTieredIdentityCache_ServesFromL1WhenL2Errors(t *testing.T) {
+	l1 := newFakeIdentityCache()
+	l1.fingerprints[3] = &Fingerprint{ClientID: "stale-but-usable"}
+	l2 := newErroringL2IdentityCache()
+	l2.getErr = errors.New("redis unavailable")
+
+	tiered := NewTieredIdentityCache(l1, l2, time.Minute)
+	// Force the tiered cache to treat L1 as stale so it must attempt L2 first.
+	tiered.cachedAt[3] = time.Now().Add(-time.Hour)
+
+	fp, err := tiered.GetFingerprint(context.Background(), 3)
+	require.NoError(t, err, "L2 outage should not propagate as an error when L1 has data")
+	require.NotNil(t, fp)
+	require.Equal(t, "stale-but-usable", fp.ClientID)
+}
+
+func TestTieredIdentityCache_L1ExpiresAfterTTL(t *testing.T) {
+	l1 := newFakeIdentityCache()
+	l2 := newFakeIdentityCache()
+	l2.fingerprints[4] = &Fingerprint{ClientID: "fresh-from-l2"}
+
+	tiered := NewTieredIdentityCache(l1, l2, time.Minute)
+	l1.fingerprints[4] = &Fingerprint{ClientID: "stale-in-l1"}
+	tiered.cachedAt[4] = time.Now().Add(-2 * time.Minute)
+
+	fp, err := tiered.GetFingerprint(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, "fresh-from-l2", fp.ClientID, "expired L1 entry should be bypassed in favor of L2")
+}
+
+func TestTieredIdentityCache_AllFingerprintsDelegatesToL2(t *testing.T) {
+	l1 := newFakeIdentityCache()
+	l2 := newFakeIdentityCache()
+	l2.fingerprints[5] = &Fingerprint{ClientID: "from-l2"}
+
+	tiered := NewTieredIdentityCache(l1, l2, time.Minute)
+	all, err := tiered.AllFingerprints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.Equal(t, "from-l2", all[5].ClientID)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BodyTransform 是一个可组合的请求体转换步骤，输入/输出均为原始 JSON 字节
+type BodyTransform func(body []byte) ([]byte, error)
+
+// Pipeline 按顺序执行一组 BodyTransform，任意一步返回错误即短路并停止后续转换
+type Pipeline struct {
+	transforms []BodyTransform
+}
+
+// NewPipeline 创建一个按给定顺序执行的转换流水线
+func NewPipeline(transforms ...BodyTransform) *Pipeline {
+	return &Pipeline{transforms: transforms}
+}
+
+// Run 依次执行流水线中的每个 BodyTransform
+func (p *Pipeline) Run(body []byte) ([]byte, error) {
+	for i, transform := range p.transforms {
+		result, err := transform(body)
+		if err != nil {
+			return nil, fmt.Errorf("body transform %d failed: %w", i, err)
+		}
+		body = result
+	}
+	return body, nil
+}
+
+// RewriteUserIDTransform 将 IdentityService.RewriteUserID 包装为 BodyTransform，
+// 便于与其他转换组合进 Pipeline
+func (s *IdentityService) RewriteUserIDTransform(accountID int64, accountUUID, cachedClientID string) BodyTransform {
+	return func(body []byte) ([]byte, error) {
+		return s.RewriteUserID(body, accountID, accountUUID, cachedClientID)
+	}
+}
+
+// SanitizeMetadataTransform 返回一个 BodyTransform，移除 metadata 中除 user_id 外的所有字段，
+// 避免客户端在 metadata 里携带的额外信息（如内部标签）被转发给上游
+func SanitizeMetadataTransform() BodyTransform {
+	return func(body []byte) ([]byte, error) {
+		if len(body) == 0 {
+			return body, nil
+		}
+
+		var reqMap map[string]any
+		if err := json.Unmarshal(body, &reqMap); err != nil {
+			return body, nil
+		}
+
+		metadata, ok := reqMap["metadata"].(map[string]any)
+		if !ok {
+			return body, nil
+		}
+
+		sanitized := map[string]any{}
+		if userID, ok := metadata["user_id"]; ok {
+			sanitized["user_id"] = userID
+		}
+		reqMap["metadata"] = sanitized
+
+		return json.Marshal(reqMap)
+	}
+}
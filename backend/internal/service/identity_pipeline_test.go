@@ -0,0 +1,70 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPipeline_RunsInOrder 校验多个 transform 按注册顺序依次执行
+func TestPipeline_RunsInOrder(t *testing.T) {
+	var order []string
+	appendStep := func(name string) BodyTransform {
+		return func(body []byte) ([]byte, error) {
+			order = append(order, name)
+			return body, nil
+		}
+	}
+
+	pipeline := NewPipeline(appendStep("first"), appendStep("second"), appendStep("third"))
+	_, err := pipeline.Run([]byte(`{}`))
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second", "third"}, order)
+}
+
+// TestPipeline_ShortCircuitsOnError 校验流水线在某一步出错时立即停止
+func TestPipeline_ShortCircuitsOnError(t *testing.T) {
+	var ran []string
+	ok := func(name string) BodyTransform {
+		return func(body []byte) ([]byte, error) {
+			ran = append(ran, name)
+			return body, nil
+		}
+	}
+	failing := func(body []byte) ([]byte, error) {
+		return nil, errors.New("boom")
+	}
+
+	pipeline := NewPipeline(ok("first"), failing, ok("never"))
+	_, err := pipeline.Run([]byte(`{}`))
+
+	require.Error(t, err)
+	require.Equal(t, []string{"first"}, ran)
+}
+
+// TestPipeline_ComposesRewriteUserIDAndSanitizeMetadata 校验组合真实转换的顺序与效果
+func TestPipeline_ComposesRewriteUserIDAndSanitizeMetadata(t *testing.T) {
+	svc := NewIdentityService(nil)
+	accountUUID := "11111111-1111-1111-1111-111111111111"
+	cachedClientID := "cachedclient"
+
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_22222222-2222-2222-2222-222222222222","internal_tag":"secret"}}`)
+
+	pipeline := NewPipeline(svc.RewriteUserIDTransform(1, accountUUID, cachedClientID), SanitizeMetadataTransform())
+	result, err := pipeline.Run(body)
+	require.NoError(t, err)
+
+	var reqMap map[string]any
+	require.NoError(t, json.Unmarshal(result, &reqMap))
+	metadata := reqMap["metadata"].(map[string]any)
+
+	require.NotContains(t, metadata, "internal_tag")
+	require.Contains(t, metadata["user_id"], "user_"+cachedClientID+"_account_"+accountUUID+"_session_")
+}
+
+const fixedHex64 = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
@@ -4,22 +4,50 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"net/http"
+	"os"
 	"regexp"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
 )
 
 // 预编译正则表达式（避免每次调用重新编译）
 var (
-	// 匹配 user_id 格式: user_{64位hex}_account__session_{uuid}
-	userIDRegex = regexp.MustCompile(`^user_[a-f0-9]{64}_account__session_([a-f0-9-]{36})$`)
+	// defaultUserIDPatterns 是 computeRewrittenUserID 默认尝试的 user_id 格式列表，按顺序匹配，
+	// 每个模式必须包含名为 session_tail 的捕获组，用作重新派生确定性 session hash 的种子。
+	defaultUserIDPatterns = []*regexp.Regexp{
+		// 旧格式: user_{64位hex}_account__session_{uuid}（account 段为空）
+		regexp.MustCompile(`^user_[a-f0-9]{64}_account__session_(?P<session_tail>[a-f0-9-]{36})$`),
+		// 新版客户端直接携带 account uuid: user_{hex}_account_{uuid}_session_{uuid}。
+		// 与本服务自身重写后的输出格式同形，因此顺带覆盖了"body 已是输出格式，仍需按
+		// accountID 重新派生确定性 session hash"的场景，无需单独处理。
+		regexp.MustCompile(`^user_[a-f0-9]+_account_.+_session_(?P<session_tail>[a-f0-9-]{36})$`),
+	}
 	// 匹配 Claude Code User-Agent 格式: claude-cli/x.y.z
-	claudeCodeUARegex = regexp.MustCompile(`^claude-cli/(\d+)\.(\d+)\.(\d+)`)
+	// 匹配 claude-cli/x.y.z[.w][-prerelease][+buildmeta] 格式：第四位数字段、预发布后缀、
+	// 构建元数据均为可选，分别对应 CompareClaudeCodeVersion 中的 a[4]（build）、
+	// a[5]（prerelease）分组；构建元数据（a[6]）按 semver 语义不参与版本比较。
+	claudeCodeUARegex = regexp.MustCompile(`^claude-cli/(\d+)\.(\d+)\.(\d+)(?:\.(\d+))?(?:-([0-9A-Za-z.]+))?(?:\+([0-9A-Za-z.]+))?`)
+	// 匹配 RewriteUserID 输出的重写后格式: user_{64位hex clientID}_account_{accountUUID}_session_{sessionHash(uuid)}
+	rewrittenUserIDRegex = regexp.MustCompile(`^user_([a-f0-9]{64})_account_(.+)_session_([a-f0-9-]{36})$`)
 )
 
 // 默认指纹值（Claude Code 客户端特征）
@@ -31,10 +59,16 @@ var defaultFingerprint = Fingerprint{
 	StainlessArch:           "x64",
 	StainlessRuntime:        "node",
 	StainlessRuntimeVersion: "v22.14.0",
+	AnthropicBeta:           "claude-code-20250219",
 }
 
+// currentFingerprintSchemaVersion 是当前 Fingerprint 的 schema 版本号。
+// 每当新增字段需要迁移时递增，并在 migrateFingerprintToCurrent 中为旧版本缺失的字段补充默认值。
+const currentFingerprintSchemaVersion = 2
+
 // Fingerprint represents account fingerprint data
 type Fingerprint struct {
+	SchemaVersion           int // 指纹 schema 版本；缓存中缺失该字段（历史数据）时反序列化为 0，视为 v1
 	ClientID                string
 	UserAgent               string
 	StainlessLang           string
@@ -43,22 +77,464 @@ type Fingerprint struct {
 	StainlessArch           string
 	StainlessRuntime        string
 	StainlessRuntimeVersion string
+	AnthropicBeta           string    // Anthropic-Beta 头的值，为空时 ApplyFingerprint 不设置该头，保留客户端原有的值
+	CreatedAt               time.Time // 指纹创建时间（v2 新增字段，迁移 v1 数据时补当前时间）
+	Pinned                  bool      // 为 true 时 GetOrCreateFingerprint 永不因客户端 UA 升级而改写该指纹
+}
+
+// migrateFingerprintToCurrent 将旧版本 schema 的指纹迁移到当前版本，为新增字段填充默认值，
+// 并返回是否发生了迁移（调用方据此决定是否需要回写缓存）。
+func migrateFingerprintToCurrent(fp *Fingerprint) bool {
+	if fp.SchemaVersion >= currentFingerprintSchemaVersion {
+		return false
+	}
+
+	// v1 -> v2: 新增 CreatedAt，缺失时补当前时间
+	if fp.CreatedAt.IsZero() {
+		fp.CreatedAt = time.Now()
+	}
+
+	fp.SchemaVersion = currentFingerprintSchemaVersion
+	return true
 }
 
 // IdentityCache defines cache operations for identity service
 type IdentityCache interface {
 	GetFingerprint(ctx context.Context, accountID int64) (*Fingerprint, error)
 	SetFingerprint(ctx context.Context, accountID int64, fp *Fingerprint) error
+	// AllFingerprints 枚举缓存中当前所有账号的指纹，键为 accountID。用于批量运维操作
+	// （如 BumpDefaultUserAgent），调用方不应假设返回顺序。
+	AllFingerprints(ctx context.Context) (map[int64]*Fingerprint, error)
+	// DeleteFingerprint 移除指定账号的缓存指纹，供账号被怀疑关联/标记时单独清理，
+	// 无需清空整个指纹缓存。指纹本不存在时视为成功（幂等）。
+	DeleteFingerprint(ctx context.Context, accountID int64) error
+}
+
+// UserIDMappingStore 持久化重写前后的 user_id 映射，用于日志排查时将上游日志关联回客户端的原始会话。
+// 实现应自行为映射设置 TTL，避免无限增长。
+type UserIDMappingStore interface {
+	RecordUserIDMapping(ctx context.Context, rewrittenUserID, originalUserID string, ttl time.Duration) error
+	LookupOriginalUserID(ctx context.Context, rewrittenUserID string) (string, error)
+}
+
+// FingerprintRepository 将账号指纹持久化到数据库，作为 IdentityCache（Redis，带 TTL）的
+// 兜底事实来源：缓存被清空后，PersistentIdentityCache 通过它找回已有指纹，避免账号
+// ClientID 被无谓地重新生成而破坏上游会话连续性。
+type FingerprintRepository interface {
+	GetFingerprint(ctx context.Context, accountID int64) (*Fingerprint, error)
+	SetFingerprint(ctx context.Context, accountID int64, fp *Fingerprint) error
+}
+
+// PersistentIdentityCache 用数据库为 IdentityCache 提供读穿透兜底：GetFingerprint 优先读缓存，
+// 未命中时回退到 repo；SetFingerprint 同时写缓存和 repo（write-through），repo 中的记录才是
+// 长期存活的事实来源，缓存过期或被清空不会造成指纹丢失。
+type PersistentIdentityCache struct {
+	cache IdentityCache
+	repo  FingerprintRepository
+}
+
+// NewPersistentIdentityCache 创建一个以 cache 为读写穿透层、repo 为持久层的 IdentityCache 实现。
+func NewPersistentIdentityCache(cache IdentityCache, repo FingerprintRepository) *PersistentIdentityCache {
+	return &PersistentIdentityCache{cache: cache, repo: repo}
+}
+
+func (c *PersistentIdentityCache) GetFingerprint(ctx context.Context, accountID int64) (*Fingerprint, error) {
+	fp, err := c.cache.GetFingerprint(ctx, accountID)
+	if err == nil && fp != nil {
+		return fp, nil
+	}
+
+	fp, err = c.repo.GetFingerprint(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if fp == nil {
+		return nil, nil
+	}
+
+	if err := c.cache.SetFingerprint(ctx, accountID, fp); err != nil {
+		log.Printf("Warning: failed to warm cache from persistent fingerprint for account %d: %v", accountID, err)
+	}
+	return fp, nil
+}
+
+func (c *PersistentIdentityCache) SetFingerprint(ctx context.Context, accountID int64, fp *Fingerprint) error {
+	if err := c.repo.SetFingerprint(ctx, accountID, fp); err != nil {
+		return err
+	}
+	if err := c.cache.SetFingerprint(ctx, accountID, fp); err != nil {
+		log.Printf("Warning: failed to write fingerprint to cache for account %d: %v", accountID, err)
+	}
+	return nil
+}
+
+func (c *PersistentIdentityCache) AllFingerprints(ctx context.Context) (map[int64]*Fingerprint, error) {
+	return c.cache.AllFingerprints(ctx)
+}
+
+// DeleteFingerprint 仅移除缓存层的指纹；repo（FingerprintRepository 未提供删除操作）中
+// 的记录保留不变，因此后续 GetFingerprint 会从 repo 读穿透重新填充缓存。
+// 需要真正让账号"忘记"既有指纹时应使用 RotateFingerprint，而不是本方法。
+func (c *PersistentIdentityCache) DeleteFingerprint(ctx context.Context, accountID int64) error {
+	return c.cache.DeleteFingerprint(ctx, accountID)
+}
+
+// accountDebugEnabled 记录当前开启了详细指纹日志的账号（map[int64]struct{}），供
+// SetAccountDebug/debugLogAccount 使用。读多写少，且读写都可能发生在请求路径上，
+// 用 sync.Map 而非加锁的 map。
+var accountDebugEnabled sync.Map
+
+// SetAccountDebug 开启或关闭指定账号的详细指纹/user_id 重写日志，用于排查单个账号的问题
+// 而不淹没全量日志。日志中的标识符会经 redactIdentifier 处理，不会完整落盘。
+func SetAccountDebug(accountID int64, enabled bool) {
+	if enabled {
+		accountDebugEnabled.Store(accountID, struct{}{})
+		return
+	}
+	accountDebugEnabled.Delete(accountID)
+}
+
+func isAccountDebugEnabled(accountID int64) bool {
+	_, ok := accountDebugEnabled.Load(accountID)
+	return ok
+}
+
+// debugLogAccount 仅在 accountID 已通过 SetAccountDebug 开启调试时打印，其余账号不受影响。
+func debugLogAccount(accountID int64, format string, args ...any) {
+	if !isAccountDebugEnabled(accountID) {
+		return
+	}
+	log.Printf("[account debug %d] "+format, append([]any{accountID}, args...)...)
+}
+
+// fingerprintFixLogInterval 是同一账号重复打印"修正 fingerprint UserAgent"日志的最小间隔。
+// 修正后的 UA 会回写缓存；回写失败（错误被忽略）时，高频访问的账号每次请求都会再次进入
+// 修正分支，不加节流会把日志刷爆。
+const fingerprintFixLogInterval = time.Minute
+
+// fingerprintFixLogged 记录每个账号最近一次打印该日志的时间，供 shouldLogFingerprintFix
+// 判断是否已在 fingerprintFixLogInterval 内打印过。写法与 accountDebugEnabled 保持一致：
+// 账号集合会随时间变化，用 sync.Map 比固定分片锁更合适。
+var fingerprintFixLogged sync.Map // map[int64]time.Time
+
+// shouldLogFingerprintFix 判断是否应该为 accountID 打印一条"修正 fingerprint"日志：
+// 该账号在 fingerprintFixLogInterval 内已经打印过时返回 false。
+func shouldLogFingerprintFix(accountID int64) bool {
+	now := time.Now()
+	if last, ok := fingerprintFixLogged.Load(accountID); ok {
+		if now.Sub(last.(time.Time)) < fingerprintFixLogInterval {
+			return false
+		}
+	}
+	fingerprintFixLogged.Store(accountID, now)
+	return true
+}
+
+// redactIdentifier 仅保留标识符首尾各 4 个字符，中间替换为 "..."，用于调试日志中避免
+// 完整暴露 ClientID/user_id 等标识符。过短的标识符直接整体替换为 "***"。
+func redactIdentifier(id string) string {
+	const keep = 4
+	if len(id) <= keep*2 {
+		return "***"
+	}
+	return id[:keep] + "..." + id[len(id)-keep:]
 }
 
 // IdentityService 管理OAuth账号的请求身份指纹
 type IdentityService struct {
-	cache IdentityCache
+	cache    IdentityCache
+	defaults Fingerprint
+
+	mappingStore UserIDMappingStore
+	mappingTTL   time.Duration
+
+	// perAccountPlatformVariation 见 WithPerAccountPlatformVariation
+	perAccountPlatformVariation bool
+
+	// tracer 见 WithTracer，默认为 no-op，不产生任何追踪开销
+	tracer claude.Tracer
+
+	// userIDPatterns 见 WithUserIDPatterns，默认使用 defaultUserIDPatterns
+	userIDPatterns []*regexp.Regexp
+
+	// uaVersionPool 见 WithUAVersionPool，默认使用 defaultUAVersionWeights
+	uaVersionPool []UAVersionWeight
+
+	// metrics 见 WithFingerprintMetrics，默认为 nil（不上报）
+	metrics FingerprintMetrics
+
+	// sessionSalt 见 WithSessionSalt，默认为空字符串（不影响哈希）
+	sessionSalt string
+
+	// overrideSecret 见 WithFingerprintOverrideSecret，默认为空字符串（该功能关闭）
+	overrideSecret string
+
+	// fingerprintLocks 是按 accountID 分片的互斥锁，供 GetOrCreateFingerprint 在读-改-写
+	// 缓存指纹时避免并发请求互相覆盖（例如两个并发请求都读到旧 UserAgent，导致后写入的
+	// 那个把先写入的版本升级覆盖掉）。见 fingerprintLock。
+	fingerprintLocks [fingerprintUpdateShards]sync.Mutex
+
+	// fingerprintGroup 对同一账号的"缓存未命中 -> 生成并写入指纹"路径按 accountID 去重：
+	// 大量并发请求同时判定为未命中时，只有一个协程真正生成 ClientID 并调用
+	// SetFingerprint，其余协程复用同一个结果，而不是各自生成一个 ClientID 后互相用
+	// SetFingerprint 覆盖，导致只有最后一次写入生效、其余请求生成的 ClientID 被立即丢弃。
+	fingerprintGroup singleflight.Group
+
+	// fingerprintTTL 见 WithFingerprintTTL，默认为 0（永不过期）
+	fingerprintTTL time.Duration
+
+	// sessionHasher 见 WithSessionHasher，默认为 UUIDv4SessionHasher
+	sessionHasher SessionHasher
+}
+
+// fingerprintUpdateShards 是 fingerprintLocks 的分片数量。使用固定数量的分片而非
+// 每账号一把锁，避免账号数量增长时锁本身无限占用内存；取 2 的幂便于用位运算取模。
+const fingerprintUpdateShards = 256
+
+// fingerprintLock 返回 accountID 对应的分片锁。同一 accountID 总是映射到同一把锁，
+// 不同 accountID 可能共享同一把锁（分片碰撞），但这只会带来偶发的、无害的额外等待，
+// 不影响正确性。
+func (s *IdentityService) fingerprintLock(accountID int64) *sync.Mutex {
+	return &s.fingerprintLocks[uint64(accountID)%fingerprintUpdateShards]
+}
+
+// WithSessionSalt 为 session hash 派生（computeRewrittenUserID 中 SHA256(accountID::sessionTail)
+// 的部分）额外混入一个实例级盐值，避免多个共享同一账号数据的 sub2api 部署为同一个
+// accountID/sessionTail 组合派生出完全相同的 session UUID，从而在上游看起来像跨实例的
+// 同一个会话。不设置该选项时 sessionSalt 为空串，seed 格式与之前完全一致，
+// 已有缓存/映射不受影响。
+func WithSessionSalt(salt string) IdentityServiceOption {
+	return func(s *IdentityService) {
+		s.sessionSalt = salt
+	}
+}
+
+// WithSessionHasher 配置 RewriteUserID/ComputeSessionHash 派生 session hash 段时使用的输出
+// 格式，默认使用 UUIDv4SessionHasher（与未配置该选项时完全一致）。部分上游网关期望不同形状
+// 的会话标识符（如紧凑十六进制、带前缀的 ID），可通过该选项适配，seed 派生逻辑本身不受影响。
+// hasher 为 nil 时忽略该选项，保留默认值。
+func WithSessionHasher(hasher SessionHasher) IdentityServiceOption {
+	return func(s *IdentityService) {
+		if hasher != nil {
+			s.sessionHasher = hasher
+		}
+	}
+}
+
+// WithFingerprintOverrideSecret 配置 ParseFingerprintOverride 校验的可信密钥。仅当请求携带的
+// X-Sub2API-Force-Secret 头与该密钥常数时间比较匹配时，其余 X-Sub2API-Force-* 覆盖头才会被
+// 采信；未配置该选项（secret 为空）时 ParseFingerprintOverride 恒返回 nil，即该功能默认关闭，
+// 避免部署方未特意配置密钥就意外把这一内部调试通道暴露出去。
+func WithFingerprintOverrideSecret(secret string) IdentityServiceOption {
+	return func(s *IdentityService) {
+		s.overrideSecret = secret
+	}
+}
+
+// sessionHashSeed 构造派生 session hash 用的种子：salt 为空时格式与历史行为完全一致
+// （"accountID::sessionTail"），非空时追加 salt 段，使不同实例产生不同哈希。
+func sessionHashSeed(accountID int64, sessionTail, salt string) string {
+	if salt == "" {
+		return fmt.Sprintf("%d::%s", accountID, sessionTail)
+	}
+	return fmt.Sprintf("%d::%s::%s", accountID, sessionTail, salt)
+}
+
+// FingerprintMetrics 接收 GetOrCreateFingerprint 的缓存命中/未命中及 RotateFingerprint 的
+// 轮换事件，供部署方对接自己的 Prometheus/其他指标系统。方法应当尽快返回（如仅
+// counter.Inc()），避免拖慢指纹读写路径。
+type FingerprintMetrics interface {
+	IncFingerprintHit()
+	IncFingerprintMiss()
+	IncFingerprintRotation()
+}
+
+// incFingerprintHit / incFingerprintMiss / incFingerprintRotation 是 s.metrics 的空值安全封装：
+// 未通过 WithFingerprintMetrics 配置时 s.metrics 为 nil，直接跳过而不是 panic。
+func (s *IdentityService) incFingerprintHit() {
+	if s.metrics != nil {
+		s.metrics.IncFingerprintHit()
+	}
+}
+
+func (s *IdentityService) incFingerprintMiss() {
+	if s.metrics != nil {
+		s.metrics.IncFingerprintMiss()
+	}
+}
+
+func (s *IdentityService) incFingerprintRotation() {
+	if s.metrics != nil {
+		s.metrics.IncFingerprintRotation()
+	}
+}
+
+// WithFingerprintMetrics 为 GetOrCreateFingerprint / RotateFingerprint 配置指标上报接收方。
+// 不设置该选项时 metrics 保持 nil，相关调用点直接跳过上报，不产生任何开销。传入 nil 会被忽略。
+func WithFingerprintMetrics(metrics FingerprintMetrics) IdentityServiceOption {
+	return func(s *IdentityService) {
+		if metrics == nil {
+			return
+		}
+		s.metrics = metrics
+	}
+}
+
+// WithFingerprintTTL 配置指纹的最长存活期：GetOrCreateFingerprint 命中一个已超过 ttl 的
+// 缓存指纹时，会就地生成一个新 ClientID（保留 UserAgent 与全部 Stainless 字段）并回写缓存，
+// 而不是让同一个 ClientID 无限期地被上游持续观测到。ttl<=0（默认值）保持"永不过期"的
+// 既有行为，不改变现有部署的表现。Pinned 指纹不受 TTL 影响，语义上等同于手工豁免轮换。
+func WithFingerprintTTL(ttl time.Duration) IdentityServiceOption {
+	return func(s *IdentityService) {
+		s.fingerprintTTL = ttl
+	}
+}
+
+// EnableUserIDMapping 开启 user_id 映射记录：此后每次 RewriteUserID 成功重写 user_id，
+// 都会将重写前后的值写入 store，保留时间为 ttl。默认不开启（mappingStore 为 nil 时 RewriteUserID 不记录）。
+func (s *IdentityService) EnableUserIDMapping(store UserIDMappingStore, ttl time.Duration) {
+	s.mappingStore = store
+	s.mappingTTL = ttl
+}
+
+// IdentityServiceOption 配置 NewIdentityService 创建的 IdentityService 实例
+type IdentityServiceOption func(*IdentityService)
+
+// WithDefaultFingerprint 用 fp 覆盖默认指纹（UserAgent 及 Stainless 系列字段），供部署方按
+// 自己代理的真实客户端环境（例如 macOS ARM 而非硬编码的 Linux/x64/node）配置默认指纹，
+// 避免与上游观察到的真实客户端环境不一致而显得可疑。fp.UserAgent 必须匹配 claude-cli/x.y.z
+// 格式，否则该选项被忽略并记录警告，与 SUB2API_DEFAULT_UA 环境变量的校验方式保持一致。
+func WithDefaultFingerprint(fp Fingerprint) IdentityServiceOption {
+	return func(s *IdentityService) {
+		if !isClaudeCodeUserAgent(fp.UserAgent) {
+			log.Printf("Warning: WithDefaultFingerprint UserAgent %q does not match claude-cli/x.y.z format, ignoring", fp.UserAgent)
+			return
+		}
+		s.defaults = fp
+	}
+}
+
+// PlatformProfile 是一组可信的操作系统/架构/运行时版本组合，用于 WithPerAccountPlatformVariation。
+type PlatformProfile struct {
+	OS             string
+	Arch           string
+	RuntimeVersion string
+}
+
+// platformProfiles 是内置的可信平台组合表：均为 Claude Code 官方支持平台上真实可能出现的组合，
+// 供 WithPerAccountPlatformVariation 按账号确定性挑选，避免账号池中所有指纹都呈现相同的
+// OS/Arch/Runtime（这是一个明显的批量账号关联信号）。
+var platformProfiles = []PlatformProfile{
+	{OS: "Linux", Arch: "x64", RuntimeVersion: "v22.14.0"},
+	{OS: "Darwin", Arch: "arm64", RuntimeVersion: "v22.14.0"},
+	{OS: "Darwin", Arch: "x64", RuntimeVersion: "v20.11.0"},
+	{OS: "Windows", Arch: "x64", RuntimeVersion: "v22.14.0"},
+	{OS: "Linux", Arch: "arm64", RuntimeVersion: "v20.11.0"},
+}
+
+// WithPerAccountPlatformVariation 让新建指纹的 StainlessOS/StainlessArch/StainlessRuntimeVersion
+// 不再固定沿用默认指纹，而是基于 accountID 从 platformProfiles 中确定性地挑选一组组合——
+// 同一账号每次都选中相同组合（会话内保持一致），但账号池整体呈现多样的平台特征。
+// 仅影响新建指纹；已缓存的指纹不会被本选项修改。
+func WithPerAccountPlatformVariation() IdentityServiceOption {
+	return func(s *IdentityService) {
+		s.perAccountPlatformVariation = true
+	}
+}
+
+// WithTracer 为 GetOrCreateFingerprint / RewriteUserID 注入分布式追踪：调用时会围绕这些操作
+// 创建 span，并记录 account_id 等属性，供操作方对接自己的追踪系统排查指纹/重写相关问题。
+// 不设置该选项时使用 no-op 实现，不产生任何开销。传入 nil 会被忽略。
+func WithTracer(tracer claude.Tracer) IdentityServiceOption {
+	return func(s *IdentityService) {
+		if tracer == nil {
+			return
+		}
+		s.tracer = tracer
+	}
+}
+
+// WithUserIDPatterns 用 patterns 覆盖 computeRewrittenUserID 尝试的 user_id 格式列表，按传入顺序
+// 依次匹配，取第一个成功匹配的模式。用于在不改代码的情况下适配新版客户端发送的 user_id 变体
+// （例如不再使用双下划线空 account 段的格式）。每个模式必须包含名为 session_tail 的捕获组，
+// 不含该分组的模式会被跳过并记录警告；patterns 为空或全部无效时保留默认列表不变。
+func WithUserIDPatterns(patterns []*regexp.Regexp) IdentityServiceOption {
+	return func(s *IdentityService) {
+		valid := make([]*regexp.Regexp, 0, len(patterns))
+		for _, p := range patterns {
+			if p.SubexpIndex("session_tail") < 0 {
+				log.Printf("Warning: WithUserIDPatterns pattern %q has no session_tail capture group, ignoring", p.String())
+				continue
+			}
+			valid = append(valid, p)
+		}
+		if len(valid) == 0 {
+			return
+		}
+		s.userIDPatterns = valid
+	}
+}
+
+// platformProfileForAccount 使用与 generateUUIDFromSeed 相同的哈希方式（sha256(seed)）
+// 从 accountID 确定性地选出一个 PlatformProfile。
+func platformProfileForAccount(accountID int64) PlatformProfile {
+	seed := fmt.Sprintf("platform-profile:%d", accountID)
+	hash := sha256.Sum256([]byte(seed))
+	idx := int(hash[0]) % len(platformProfiles)
+	return platformProfiles[idx]
 }
 
 // NewIdentityService 创建新的IdentityService
-func NewIdentityService(cache IdentityCache) *IdentityService {
-	return &IdentityService{cache: cache}
+// 默认指纹字段可通过环境变量覆盖，便于容器化部署时无需改代码/配置文件即可调整：
+//   - SUB2API_DEFAULT_UA: 默认 User-Agent（须匹配 claude-cli/x.y.z 格式）
+//   - SUB2API_STAINLESS_LANG / SUB2API_STAINLESS_PACKAGE_VERSION
+//   - SUB2API_STAINLESS_OS / SUB2API_STAINLESS_ARCH
+//   - SUB2API_STAINLESS_RUNTIME / SUB2API_STAINLESS_RUNTIME_VERSION
+//
+// 未设置的变量沿用硬编码默认值；opts 中的 WithDefaultFingerprint（如果提供）在环境变量之后
+// 应用，优先级最高。不传入任何 opts 时行为与之前完全一致。
+func NewIdentityService(cache IdentityCache, opts ...IdentityServiceOption) *IdentityService {
+	s := &IdentityService{cache: cache, defaults: defaultFingerprintFromEnv(), tracer: claude.NoopTracer(), userIDPatterns: defaultUserIDPatterns, uaVersionPool: defaultUAVersionWeights, sessionHasher: UUIDv4SessionHasher}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// defaultFingerprintFromEnv 从环境变量构建默认指纹，未设置或校验失败的字段回退到硬编码默认值
+func defaultFingerprintFromEnv() Fingerprint {
+	fp := defaultFingerprint
+
+	if ua := strings.TrimSpace(os.Getenv("SUB2API_DEFAULT_UA")); ua != "" {
+		if isClaudeCodeUserAgent(ua) {
+			fp.UserAgent = ua
+		} else {
+			log.Printf("Warning: SUB2API_DEFAULT_UA %q does not match claude-cli/x.y.z format, ignoring", ua)
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("SUB2API_STAINLESS_LANG")); v != "" {
+		fp.StainlessLang = v
+	}
+	if v := strings.TrimSpace(os.Getenv("SUB2API_STAINLESS_PACKAGE_VERSION")); v != "" {
+		fp.StainlessPackageVersion = v
+	}
+	if v := strings.TrimSpace(os.Getenv("SUB2API_STAINLESS_OS")); v != "" {
+		fp.StainlessOS = v
+	}
+	if v := strings.TrimSpace(os.Getenv("SUB2API_STAINLESS_ARCH")); v != "" {
+		fp.StainlessArch = v
+	}
+	if v := strings.TrimSpace(os.Getenv("SUB2API_STAINLESS_RUNTIME")); v != "" {
+		fp.StainlessRuntime = v
+	}
+	if v := strings.TrimSpace(os.Getenv("SUB2API_STAINLESS_RUNTIME_VERSION")); v != "" {
+		fp.StainlessRuntimeVersion = v
+	}
+	if v := strings.TrimSpace(os.Getenv("SUB2API_ANTHROPIC_BETA")); v != "" {
+		fp.AnthropicBeta = v
+	}
+
+	return fp
 }
 
 // GetOrCreateFingerprint 获取或创建账号的指纹
@@ -67,163 +543,1247 @@ func NewIdentityService(cache IdentityCache) *IdentityService {
 // 2. 如果不是（如 SillyTavern），使用缓存的 Claude Code User-Agent
 // 3. 这样真正的 Claude Code 客户端可以自动升级版本，其他客户端也能正常工作
 func (s *IdentityService) GetOrCreateFingerprint(ctx context.Context, accountID int64, headers http.Header) (*Fingerprint, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "identity.GetOrCreateFingerprint")
+	defer span.End()
+	span.SetAttribute("account_id", accountID)
+
 	clientUA := headers.Get("User-Agent")
 	isRealClaudeCode := isClaudeCodeUserAgent(clientUA)
+	span.SetAttribute("genuine", isRealClaudeCode)
+
+	// 本函数只做缓存读取/写入，不发起上游请求，因此可以直接把整个读-改-写临界区
+	// 都纳入锁保护范围，避免并发请求对同一账号的指纹产生丢失更新。
+	lock := s.fingerprintLock(accountID)
+	lock.Lock()
+	defer lock.Unlock()
 
-	// 尝试从缓存获取指纹
 	cached, err := s.cache.GetFingerprint(ctx, accountID)
 	if err == nil && cached != nil {
+		return s.reconcileFingerprint(ctx, accountID, cached, clientUA, isRealClaudeCode)
+	}
+
+	// 缓存确实不存在：用 singleflight 按账号去重，避免同一批并发请求各自生成一个
+	// ClientID 再互相用 SetFingerprint 覆盖——只有最后一次写入生效，其余请求白白
+	// 生成的 ClientID 立刻被丢弃。
+	v, err, _ := s.fingerprintGroup.Do(strconv.FormatInt(accountID, 10), func() (any, error) {
+		newUA := s.defaults.UserAgent
 		if isRealClaudeCode {
-			// 真正的 Claude Code 客户端：检查是否需要更新版本
-			if isNewerClaudeCodeVersion(clientUA, cached.UserAgent) {
-				cached.UserAgent = clientUA
-				_ = s.cache.SetFingerprint(ctx, accountID, cached)
-				log.Printf("Updated fingerprint User-Agent for account %d: %s", accountID, clientUA)
-			}
+			newUA = clientUA
+		}
+		fp := s.newFingerprintForAccount(accountID, newUA)
+
+		// 保存到缓存（过期时间由 IdentityCache 实现决定）
+		if err := s.cache.SetFingerprint(ctx, accountID, fp); err != nil {
+			log.Printf("Warning: failed to cache fingerprint for account %d: %v", accountID, err)
+		}
+
+		log.Printf("Created new fingerprint for account %d with client_id: %s, user_agent: %s", accountID, fp.ClientID, fp.UserAgent)
+		debugLogAccount(accountID, "created fingerprint: client_id=%s user_agent=%s genuine=%v", redactIdentifier(fp.ClientID), fp.UserAgent, isRealClaudeCode)
+		s.incFingerprintMiss()
+		return fp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Fingerprint), nil
+}
+
+// reconcileFingerprint 处理缓存命中的情况：按需迁移 schema、修正低熵 ClientID、
+// 按 fingerprintTTL 轮换过期 ClientID、纠正/升级 UserAgent，必要时回写缓存。
+// 调用方必须已持有 accountID 对应的分片锁。
+func (s *IdentityService) reconcileFingerprint(ctx context.Context, accountID int64, cached *Fingerprint, clientUA string, isRealClaudeCode bool) (*Fingerprint, error) {
+	if migrateFingerprintToCurrent(cached) {
+		if err := s.cache.SetFingerprint(ctx, accountID, cached); err != nil {
+			log.Printf("Warning: failed to persist migrated fingerprint for account %d: %v", accountID, err)
+		} else {
+			log.Printf("Migrated fingerprint schema for account %d to v%d", accountID, currentFingerprintSchemaVersion)
+		}
+	}
+	if !hasSufficientEntropy(cached.ClientID) {
+		// 缓存中的 ClientID 可能来自手工设置或历史迁移数据，熵不足会削弱指纹的可区分性，
+		// 在这里重新生成一个符合真随机要求的值并回写缓存。
+		cached.ClientID = generateClientIDForAccount(accountID)
+		if err := s.cache.SetFingerprint(ctx, accountID, cached); err != nil {
+			log.Printf("Warning: failed to persist regenerated client_id for account %d: %v", accountID, err)
 		} else {
-			// 非 Claude Code 客户端：确保使用 Claude Code User-Agent
-			// 如果缓存的不是 Claude Code 格式，强制使用默认值
-			if !isClaudeCodeUserAgent(cached.UserAgent) {
-				cached.UserAgent = defaultFingerprint.UserAgent
-				_ = s.cache.SetFingerprint(ctx, accountID, cached)
+			log.Printf("Regenerated low-entropy client_id for account %d", accountID)
+		}
+	}
+	if !cached.Pinned && s.fingerprintTTL > 0 && time.Since(cached.CreatedAt) > s.fingerprintTTL {
+		// 超过配置的最长存活期：就地生成新 ClientID，保留 UserAgent/Stainless 字段不变，
+		// 避免长期不变的 ClientID 本身成为上游可关联的信号；比 RotateFingerprint 更轻量，
+		// 不随之重新随机选取平台组合，因为这是自动触发而非账号已被标记/怀疑关联的场景。
+		cached.ClientID = generateClientIDForAccount(accountID)
+		cached.CreatedAt = time.Now()
+		if err := s.cache.SetFingerprint(ctx, accountID, cached); err != nil {
+			log.Printf("Warning: failed to persist TTL-rotated client_id for account %d: %v", accountID, err)
+		} else {
+			log.Printf("Rotated expired fingerprint client_id for account %d (ttl=%s)", accountID, s.fingerprintTTL)
+		}
+		s.incFingerprintRotation()
+	}
+
+	if cached.Pinned {
+		// 已固定的指纹：跳过版本升级/纠正逻辑，UserAgent 保持不变，
+		// 避免账号因 Claude Code 客户端切换/升级而被上游关联到同一账号的异常行为。
+	} else if isRealClaudeCode {
+		// 真正的 Claude Code 客户端：检查是否需要更新版本
+		if isNewerClaudeCodeVersion(clientUA, cached.UserAgent) {
+			cached.UserAgent = clientUA
+			_ = s.cache.SetFingerprint(ctx, accountID, cached)
+			log.Printf("Updated fingerprint User-Agent for account %d: %s", accountID, clientUA)
+		}
+	} else {
+		// 非 Claude Code 客户端：确保使用 Claude Code User-Agent
+		// 如果缓存的不是 Claude Code 格式，强制使用默认值
+		if !isClaudeCodeUserAgent(cached.UserAgent) {
+			cached.UserAgent = s.defaults.UserAgent
+			_ = s.cache.SetFingerprint(ctx, accountID, cached)
+			if shouldLogFingerprintFix(accountID) {
 				log.Printf("Fixed fingerprint User-Agent to default for account %d", accountID)
 			}
 		}
-		return cached, nil
 	}
+	debugLogAccount(accountID, "cache hit: client_id=%s user_agent=%s genuine=%v pinned=%v",
+		redactIdentifier(cached.ClientID), cached.UserAgent, isRealClaudeCode, cached.Pinned)
+	s.incFingerprintHit()
+	return cached, nil
+}
 
-	// 缓存不存在或解析失败，创建新指纹
+// newFingerprintForAccount 构建一个全新指纹：Stainless 系列字段取自 s.defaults（或
+// perAccountPlatformVariation 开启时按 accountID 确定性选取的平台组合），UserAgent 使用
+// userAgent，ClientID 随机生成。供 GetOrCreateFingerprint 的缓存未命中分支及 WarmFingerprints
+// 共用，不写入缓存，调用方负责持久化。
+func (s *IdentityService) newFingerprintForAccount(accountID int64, userAgent string) *Fingerprint {
 	fp := &Fingerprint{
-		StainlessLang:           defaultFingerprint.StainlessLang,
-		StainlessPackageVersion: defaultFingerprint.StainlessPackageVersion,
-		StainlessOS:             defaultFingerprint.StainlessOS,
-		StainlessArch:           defaultFingerprint.StainlessArch,
-		StainlessRuntime:        defaultFingerprint.StainlessRuntime,
-		StainlessRuntimeVersion: defaultFingerprint.StainlessRuntimeVersion,
+		SchemaVersion:           currentFingerprintSchemaVersion,
+		CreatedAt:               time.Now(),
+		UserAgent:               userAgent,
+		StainlessLang:           s.defaults.StainlessLang,
+		StainlessPackageVersion: s.defaults.StainlessPackageVersion,
+		StainlessOS:             s.defaults.StainlessOS,
+		StainlessArch:           s.defaults.StainlessArch,
+		StainlessRuntime:        s.defaults.StainlessRuntime,
+		StainlessRuntimeVersion: s.defaults.StainlessRuntimeVersion,
+		AnthropicBeta:           s.defaults.AnthropicBeta,
 	}
 
-	// 如果是真正的 Claude Code 客户端，使用它的 User-Agent；否则使用默认值
-	if isRealClaudeCode {
-		fp.UserAgent = clientUA
-	} else {
-		fp.UserAgent = defaultFingerprint.UserAgent
+	if s.perAccountPlatformVariation {
+		profile := platformProfileForAccount(accountID)
+		fp.StainlessOS = profile.OS
+		fp.StainlessArch = profile.Arch
+		fp.StainlessRuntimeVersion = profile.RuntimeVersion
 	}
 
-	// 生成随机ClientID
-	fp.ClientID = generateClientID()
+	fp.ClientID = generateClientIDForAccount(accountID)
+	return fp
+}
 
-	// 保存到缓存（永不过期）
-	if err := s.cache.SetFingerprint(ctx, accountID, fp); err != nil {
-		log.Printf("Warning: failed to cache fingerprint for account %d: %v", accountID, err)
+// WarmFingerprints 为 accountIDs 中尚未有缓存指纹的账号预先创建并缓存指纹，使冷启动后的
+// 第一批请求也能直接命中缓存，而不是在突发流量下并发触发 GetOrCreateFingerprint 的
+// 创建路径。已有缓存指纹的账号会被跳过、保持不变。可安全地并发调用（内部按账号加锁，
+// 与 GetOrCreateFingerprint 共用同一把分片锁）。部分账号失败不会中止其余账号的预热，
+// 所有失败原因通过 errors.Join 合并后返回；全部成功时返回 nil。
+func (s *IdentityService) WarmFingerprints(ctx context.Context, accountIDs []int64) error {
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+
+	wg.Add(len(accountIDs))
+	for _, accountID := range accountIDs {
+		go func(accountID int64) {
+			defer wg.Done()
+			if err := s.warmFingerprint(ctx, accountID); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(accountID)
 	}
+	wg.Wait()
 
-	log.Printf("Created new fingerprint for account %d with client_id: %s, user_agent: %s", accountID, fp.ClientID, fp.UserAgent)
-	return fp, nil
+	return errors.Join(errs...)
 }
 
-// ApplyFingerprint 将指纹应用到请求头（覆盖原有的x-stainless-*头）
-func (s *IdentityService) ApplyFingerprint(req *http.Request, fp *Fingerprint) {
-	if fp == nil {
-		return
+// warmFingerprint 为单个账号执行 WarmFingerprints 的实际预热逻辑。
+func (s *IdentityService) warmFingerprint(ctx context.Context, accountID int64) error {
+	lock := s.fingerprintLock(accountID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	cached, err := s.cache.GetFingerprint(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("warm fingerprint: read cache for account %d: %w", accountID, err)
+	}
+	if cached != nil {
+		return nil
 	}
 
-	// 设置user-agent
-	if fp.UserAgent != "" {
-		req.Header.Set("user-agent", fp.UserAgent)
+	fp := s.newFingerprintForAccount(accountID, s.defaults.UserAgent)
+	if err := s.cache.SetFingerprint(ctx, accountID, fp); err != nil {
+		return fmt.Errorf("warm fingerprint: persist fingerprint for account %d: %w", accountID, err)
 	}
+	debugLogAccount(accountID, "warmed fingerprint: client_id=%s user_agent=%s", redactIdentifier(fp.ClientID), fp.UserAgent)
+	return nil
+}
 
-	// 设置x-stainless-*头
-	if fp.StainlessLang != "" {
-		req.Header.Set("X-Stainless-Lang", fp.StainlessLang)
+// randomPlatformProfile 从 platformProfiles 中随机（而非按 accountID 确定性地）挑选一组组合，
+// 供 RotateFingerprint 使用——轮换的目的正是让新指纹与旧指纹不再关联，因此不能像
+// platformProfileForAccount 那样每次都算出同一个结果。
+func randomPlatformProfile() PlatformProfile {
+	b := make([]byte, 1)
+	if _, err := io.ReadFull(entropySource, b); err != nil {
+		log.Printf("Warning: crypto/rand.Read failed selecting random platform profile: %v, using first profile", err)
+		return platformProfiles[0]
 	}
-	if fp.StainlessPackageVersion != "" {
-		req.Header.Set("X-Stainless-Package-Version", fp.StainlessPackageVersion)
+	return platformProfiles[int(b[0])%len(platformProfiles)]
+}
+
+// UAVersionWeight 描述 UA 随机池中的一个候选版本及其被选中的相对权重（非概率，
+// RandomUserAgentFromPool 内部会按权重总和归一化）。
+type UAVersionWeight struct {
+	UserAgent string
+	Weight    float64
+}
+
+// uaVersionDecayFactor 是 buildDecayingUAWeights 里相邻版本间的权重衰减比例：越旧的版本
+// 权重越低。均匀采样会让老版本出现的频率与真实 Claude Code 用户的版本分布明显不符
+// （真实用户里越新的版本占比越高），这本身就是一个可被上游识别的信号。
+const uaVersionDecayFactor = 0.6
+
+// defaultUAVersionWeights 内置的 UA 随机池，按发布顺序由旧到新排列并做指数衰减加权。
+var defaultUAVersionWeights = buildDecayingUAWeights([]string{
+	"claude-cli/1.5.0 (external, cli)",
+	"claude-cli/1.6.0 (external, cli)",
+	"claude-cli/2.0.0 (external, cli)",
+	"claude-cli/2.0.62 (external, cli)",
+})
+
+// buildDecayingUAWeights 为 versionsOldToNew（由旧到新）中的每个版本分配权重，最新版本
+// 权重最高，每往前一个版本乘以 uaVersionDecayFactor。
+func buildDecayingUAWeights(versionsOldToNew []string) []UAVersionWeight {
+	weights := make([]UAVersionWeight, len(versionsOldToNew))
+	w := 1.0
+	for i := len(versionsOldToNew) - 1; i >= 0; i-- {
+		weights[i] = UAVersionWeight{UserAgent: versionsOldToNew[i], Weight: w}
+		w *= uaVersionDecayFactor
 	}
-	if fp.StainlessOS != "" {
-		req.Header.Set("X-Stainless-OS", fp.StainlessOS)
+	return weights
+}
+
+// WithUAVersionPool 覆盖默认的 UA 随机池及各版本权重，供部署方按自己观测到的真实版本
+// 分布调整（例如老版本用户全部升级后，应把对应权重降为 0 甚至从池中移除）。
+// 列表为空或权重总和不为正数时该 Option 被忽略，沿用默认池。
+func WithUAVersionPool(weights []UAVersionWeight) IdentityServiceOption {
+	return func(s *IdentityService) {
+		var total float64
+		for _, w := range weights {
+			total += w.Weight
+		}
+		if len(weights) == 0 || total <= 0 {
+			log.Printf("Warning: WithUAVersionPool given an empty or non-positive-weight pool, ignoring")
+			return
+		}
+		s.uaVersionPool = weights
 	}
-	if fp.StainlessArch != "" {
-		req.Header.Set("X-Stainless-Arch", fp.StainlessArch)
+}
+
+// RandomUserAgentFromPool 按配置的权重从 UA 随机池中挑选一个 User-Agent，用于需要批量
+// 生成指纹并呈现多版本客户端分布的场景，而不是让所有指纹都固定使用同一个默认 UA。
+// 池为空时回退到默认指纹的 UserAgent。
+func (s *IdentityService) RandomUserAgentFromPool() string {
+	pool := s.uaVersionPool
+	if len(pool) == 0 {
+		return s.defaults.UserAgent
 	}
-	if fp.StainlessRuntime != "" {
-		req.Header.Set("X-Stainless-Runtime", fp.StainlessRuntime)
+
+	var total float64
+	for _, w := range pool {
+		total += w.Weight
 	}
-	if fp.StainlessRuntimeVersion != "" {
-		req.Header.Set("X-Stainless-Runtime-Version", fp.StainlessRuntimeVersion)
+
+	b := make([]byte, 8)
+	if _, err := io.ReadFull(entropySource, b); err != nil {
+		log.Printf("Warning: crypto/rand.Read failed selecting random UA from pool: %v, using highest-weight entry", err)
+		return highestWeightUA(pool)
 	}
+	r := (float64(binary.BigEndian.Uint64(b)) / float64(math.MaxUint64)) * total
 
-	// Claude Code 客户端必需的额外头
-	req.Header.Set("X-Stainless-Retry-Count", "0")
-	req.Header.Set("X-Stainless-Timeout", "60")
-	req.Header.Set("X-App", "cli")
-	req.Header.Set("Anthropic-Dangerous-Direct-Browser-Access", "true")
+	var cumulative float64
+	for _, w := range pool {
+		cumulative += w.Weight
+		if r < cumulative {
+			return w.UserAgent
+		}
+	}
+	return pool[len(pool)-1].UserAgent
 }
 
-// RewriteUserID 重写body中的metadata.user_id
-// 输入格式：user_{clientId}_account__session_{sessionUUID}
-// 输出格式：user_{cachedClientID}_account_{accountUUID}_session_{newHash}
-func (s *IdentityService) RewriteUserID(body []byte, accountID int64, accountUUID, cachedClientID string) ([]byte, error) {
-	if len(body) == 0 || accountUUID == "" || cachedClientID == "" {
-		return body, nil
+// highestWeightUA 返回池中权重最高的 User-Agent，作为熵源不可用时的确定性回退。
+func highestWeightUA(pool []UAVersionWeight) string {
+	best := pool[0]
+	for _, w := range pool[1:] {
+		if w.Weight > best.Weight {
+			best = w
+		}
 	}
+	return best.UserAgent
+}
 
-	// 解析JSON
-	var reqMap map[string]any
-	if err := json.Unmarshal(body, &reqMap); err != nil {
-		return body, nil
+// RotateFingerprint 为被上游标记/怀疑关联的账号生成一套全新指纹：新的 ClientID、随机挑选的
+// Stainless 平台组合，覆盖缓存中的现有记录并返回新值。若已缓存的指纹带有真实 Claude Code
+// UserAgent，轮换后予以保留，避免版本号回退到默认值这一本身就异常的信号；否则使用默认 UA。
+func (s *IdentityService) RotateFingerprint(ctx context.Context, accountID int64) (*Fingerprint, error) {
+	cached, err := s.cache.GetFingerprint(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("rotate fingerprint: read existing fingerprint for account %d: %w", accountID, err)
 	}
 
-	metadata, ok := reqMap["metadata"].(map[string]any)
-	if !ok {
-		return body, nil
+	fp := &Fingerprint{
+		SchemaVersion:           currentFingerprintSchemaVersion,
+		CreatedAt:               time.Now(),
+		StainlessLang:           s.defaults.StainlessLang,
+		StainlessPackageVersion: s.defaults.StainlessPackageVersion,
+		StainlessRuntime:        s.defaults.StainlessRuntime,
+		UserAgent:               s.defaults.UserAgent,
+		AnthropicBeta:           s.defaults.AnthropicBeta,
 	}
-
-	userID, ok := metadata["user_id"].(string)
-	if !ok || userID == "" {
-		return body, nil
+	if cached != nil && isClaudeCodeUserAgent(cached.UserAgent) {
+		fp.UserAgent = cached.UserAgent
 	}
 
-	// 匹配格式: user_{64位hex}_account__session_{uuid}
-	matches := userIDRegex.FindStringSubmatch(userID)
-	if matches == nil {
-		return body, nil
-	}
+	profile := randomPlatformProfile()
+	fp.StainlessOS = profile.OS
+	fp.StainlessArch = profile.Arch
+	fp.StainlessRuntimeVersion = profile.RuntimeVersion
 
-	sessionTail := matches[1] // 原始session UUID
+	fp.ClientID = generateClientIDForAccount(accountID)
 
-	// 生成新的session hash: SHA256(accountID::sessionTail) -> UUID格式
-	seed := fmt.Sprintf("%d::%s", accountID, sessionTail)
-	newSessionHash := generateUUIDFromSeed(seed)
+	if err := s.cache.SetFingerprint(ctx, accountID, fp); err != nil {
+		return nil, fmt.Errorf("rotate fingerprint: persist new fingerprint for account %d: %w", accountID, err)
+	}
 
-	// 构建新的user_id
-	// 格式: user_{cachedClientID}_account_{account_uuid}_session_{newSessionHash}
-	newUserID := fmt.Sprintf("user_%s_account_%s_session_%s", cachedClientID, accountUUID, newSessionHash)
+	log.Printf("Rotated fingerprint for account %d, new client_id: %s", accountID, fp.ClientID)
+	debugLogAccount(accountID, "rotated fingerprint: client_id=%s user_agent=%s", redactIdentifier(fp.ClientID), fp.UserAgent)
+	s.incFingerprintRotation()
+	return fp, nil
+}
 
-	metadata["user_id"] = newUserID
-	reqMap["metadata"] = metadata
+// BumpDefaultUserAgent 批量将所有缓存指纹升级到 newUA：newUA 必须是合法的 Claude Code
+// User-Agent（claude-cli/x.y.z 格式），版本已不低于 newUA 的账号保持不变。返回实际更新的账号数。
+func (s *IdentityService) BumpDefaultUserAgent(ctx context.Context, newUA string) (int, error) {
+	if !isClaudeCodeUserAgent(newUA) {
+		return 0, fmt.Errorf("invalid Claude Code user agent: %q", newUA)
+	}
 
-	return json.Marshal(reqMap)
-}
+	fingerprints, err := s.cache.AllFingerprints(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("enumerate fingerprints: %w", err)
+	}
 
-// generateClientID 生成64位十六进制客户端ID（32字节随机数）
-func generateClientID() string {
-	b := make([]byte, 32)
-	if _, err := rand.Read(b); err != nil {
-		// 极罕见的情况，使用时间戳+固定值作为fallback
-		log.Printf("Warning: crypto/rand.Read failed: %v, using fallback", err)
-		// 使用SHA256(当前纳秒时间)作为fallback
-		h := sha256.Sum256([]byte(fmt.Sprintf("%d", time.Now().UnixNano())))
-		return hex.EncodeToString(h[:])
+	updated := 0
+	for accountID, fp := range fingerprints {
+		if fp == nil || !isNewerClaudeCodeVersion(newUA, fp.UserAgent) {
+			continue
+		}
+		fp.UserAgent = newUA
+		if err := s.cache.SetFingerprint(ctx, accountID, fp); err != nil {
+			log.Printf("Warning: failed to persist bumped user agent for account %d: %v", accountID, err)
+			continue
+		}
+		updated++
 	}
-	return hex.EncodeToString(b)
+
+	log.Printf("BumpDefaultUserAgent: updated %d/%d fingerprints to %s", updated, len(fingerprints), newUA)
+	return updated, nil
 }
 
-// generateUUIDFromSeed 从种子生成确定性UUID v4格式字符串
-func generateUUIDFromSeed(seed string) string {
-	hash := sha256.Sum256([]byte(seed))
-	bytes := hash[:16]
+// FleetStats 汇总整个账号池的指纹健康状况，供运维面板展示身份多样性与陈旧程度
+type FleetStats struct {
+	Total int // 缓存中的指纹总数
 
-	// 设置UUID v4版本和变体位
+	// ByUserAgent 按 User-Agent（近似 Claude Code 版本）统计指纹数量
+	ByUserAgent map[string]int
+
+	// OldestAge/NewestAge 是最旧/最新指纹距今的存活时长，仅统计带 CreatedAt 的指纹
+	// （v1 迁移前的历史数据没有该字段）。指纹总数为 0 或均无 CreatedAt 时两者都为 0。
+	OldestAge time.Duration
+	NewestAge time.Duration
+
+	// NonDefaultCount 是 User-Agent 与当前默认指纹不同的账号数，反映指纹相较默认值的分化程度
+	NonDefaultCount int
+}
+
+const (
+	// riskWeightStaleUA 等四个权重按风险信号的独立性简单相加，不做归一化建模；
+	// 命中越多信号风险越高，最终统一在 FingerprintRiskScore 里夹到 [0,1]。
+	riskWeightStaleUA         = 0.35
+	riskWeightDuplicatedShape = 0.35
+	riskWeightDefaultOnly     = 0.2
+	riskWeightInconsistent    = 0.3
+
+	// riskDuplicateShapeThreshold 是同一指纹「形状」（UA+平台字段完全相同）在整个账号池中
+	// 出现的次数达到该值即视为扎堆，扎堆越明显越容易被上游识别为同一批设备。
+	riskDuplicateShapeThreshold = 3
+)
+
+// FingerprintRiskScore 评估指定账号当前指纹被上游识别为「非真实客户端」的风险，返回
+// [0,1] 的分值（越高越可疑）和触发的具体原因，供运维面板排查或触发 RotateFingerprint。
+// 账号没有缓存指纹时返回 (0, nil, nil)，不视为错误。
+func (s *IdentityService) FingerprintRiskScore(ctx context.Context, accountID int64) (float64, []string, error) {
+	fp, err := s.cache.GetFingerprint(ctx, accountID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("fingerprint risk score: read fingerprint for account %d: %w", accountID, err)
+	}
+	if fp == nil {
+		return 0, nil, nil
+	}
+
+	var score float64
+	var reasons []string
+
+	if isClaudeCodeUserAgent(fp.UserAgent) && isClaudeCodeUserAgent(s.defaults.UserAgent) {
+		if cmp, ok := CompareClaudeCodeVersion(s.defaults.UserAgent, fp.UserAgent); ok && cmp > 0 {
+			score += riskWeightStaleUA
+			reasons = append(reasons, "stale-user-agent")
+		}
+	}
+
+	if fp.UserAgent == s.defaults.UserAgent &&
+		fp.StainlessOS == s.defaults.StainlessOS &&
+		fp.StainlessArch == s.defaults.StainlessArch &&
+		fp.StainlessRuntimeVersion == s.defaults.StainlessRuntimeVersion {
+		score += riskWeightDefaultOnly
+		reasons = append(reasons, "default-only")
+	}
+
+	if !hasSufficientEntropy(fp.ClientID) {
+		score += riskWeightInconsistent
+		reasons = append(reasons, "inconsistent-fields")
+	}
+
+	fingerprints, err := s.cache.AllFingerprints(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("fingerprint risk score: enumerate fleet for account %d: %w", accountID, err)
+	}
+	shape := fingerprintShapeKey(fp)
+	shapeCount := 0
+	for _, other := range fingerprints {
+		if other != nil && fingerprintShapeKey(other) == shape {
+			shapeCount++
+		}
+	}
+	if shapeCount >= riskDuplicateShapeThreshold {
+		score += riskWeightDuplicatedShape
+		reasons = append(reasons, "duplicated-shape")
+	}
+
+	if score > 1 {
+		score = 1
+	}
+	return score, reasons, nil
+}
+
+// fingerprintShapeKey 归纳出决定指纹「形状」的字段组合，用于在账号池内检测扎堆
+// （大量账号使用完全相同的 UA + 平台组合，容易被上游按设备指纹聚类识别）。
+func fingerprintShapeKey(fp *Fingerprint) string {
+	return fp.UserAgent + "|" + fp.StainlessOS + "|" + fp.StainlessArch + "|" + fp.StainlessRuntime + "|" + fp.StainlessRuntimeVersion
+}
+
+// FleetFingerprintStats 枚举缓存中所有指纹并汇总为 FleetStats，用于运维面板观察账号池的
+// 身份多样性（版本分布、与默认值的差异）和陈旧程度（指纹存活时长）。
+func (s *IdentityService) FleetFingerprintStats(ctx context.Context) (FleetStats, error) {
+	fingerprints, err := s.cache.AllFingerprints(ctx)
+	if err != nil {
+		return FleetStats{}, fmt.Errorf("enumerate fingerprints: %w", err)
+	}
+
+	stats := FleetStats{ByUserAgent: make(map[string]int)}
+	now := time.Now()
+	var oldest, newest time.Time
+
+	for _, fp := range fingerprints {
+		if fp == nil {
+			continue
+		}
+		stats.Total++
+		stats.ByUserAgent[fp.UserAgent]++
+		if fp.UserAgent != s.defaults.UserAgent {
+			stats.NonDefaultCount++
+		}
+		if fp.CreatedAt.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || fp.CreatedAt.Before(oldest) {
+			oldest = fp.CreatedAt
+		}
+		if newest.IsZero() || fp.CreatedAt.After(newest) {
+			newest = fp.CreatedAt
+		}
+	}
+
+	if !oldest.IsZero() {
+		stats.OldestAge = now.Sub(oldest)
+	}
+	if !newest.IsZero() {
+		stats.NewestAge = now.Sub(newest)
+	}
+
+	return stats, nil
+}
+
+// ApplyFingerprint 将指纹应用到请求头（覆盖原有的x-stainless-*头）
+func (s *IdentityService) ApplyFingerprint(req *http.Request, fp *Fingerprint) {
+	s.ApplyFingerprintWithOptions(req, fp, 0, ApplyOptions{})
+}
+
+// ApplyFingerprintWithRetry 与 ApplyFingerprint 相同，但 X-Stainless-Retry-Count
+// 设置为 attempt 而非固定的 0。上游重试时真实客户端会带上递增的重试次数，
+// 若每次重试都发送 0 会成为可被识别的特征，因此重试路径应传入实际的尝试次数。
+func (s *IdentityService) ApplyFingerprintWithRetry(req *http.Request, fp *Fingerprint, attempt int) {
+	s.ApplyFingerprintWithOptions(req, fp, attempt, ApplyOptions{})
+}
+
+// ApplyOptions 控制 ApplyFingerprintWithOptions 应用请求头的方式。
+type ApplyOptions struct {
+	// OnlyIfMissing 为 true 时，每个头只在请求当前还没有该头时才设置，已存在的值保持不变；
+	// 默认（false）无条件覆盖，与 ApplyFingerprint/ApplyFingerprintWithRetry 的既有行为一致。
+	// 用于中继链路中下游 sub2api 已经应用过正确指纹的场景——例如重试层已经把
+	// X-Stainless-Retry-Count 改写为实际的尝试次数，这一跳不应该把它重置回 attempt 参数。
+	OnlyIfMissing bool
+}
+
+// ApplyFingerprintWithOptions 与 ApplyFingerprintWithRetry 行为一致，但可通过 opts 定制
+// 应用方式（见 ApplyOptions）。ApplyFingerprint 与 ApplyFingerprintWithRetry 均委托于此。
+func (s *IdentityService) ApplyFingerprintWithOptions(req *http.Request, fp *Fingerprint, attempt int, opts ApplyOptions) {
+	if fp == nil {
+		return
+	}
+
+	scrubUnknownStainlessHeaders(req.Header)
+	stripFingerprintOverrideHeaders(req.Header)
+
+	setHeader := req.Header.Set
+	if opts.OnlyIfMissing {
+		setHeader = func(key, value string) {
+			if req.Header.Get(key) == "" {
+				req.Header.Set(key, value)
+			}
+		}
+	}
+
+	// 设置user-agent
+	if fp.UserAgent != "" {
+		setHeader("user-agent", fp.UserAgent)
+	}
+
+	// 设置x-stainless-*头
+	if fp.StainlessLang != "" {
+		setHeader("X-Stainless-Lang", fp.StainlessLang)
+	}
+	if fp.StainlessPackageVersion != "" {
+		setHeader("X-Stainless-Package-Version", fp.StainlessPackageVersion)
+	}
+	if fp.StainlessOS != "" {
+		setHeader("X-Stainless-OS", fp.StainlessOS)
+	}
+	if fp.StainlessArch != "" {
+		setHeader("X-Stainless-Arch", fp.StainlessArch)
+	}
+	if fp.StainlessRuntime != "" {
+		setHeader("X-Stainless-Runtime", fp.StainlessRuntime)
+	}
+	if fp.StainlessRuntimeVersion != "" {
+		setHeader("X-Stainless-Runtime-Version", fp.StainlessRuntimeVersion)
+	}
+
+	// Anthropic-Beta 为空时保留客户端原有的值：字段为空通常意味着该指纹尚未迁移/未配置默认值，
+	// 贸然清空会比一个不完全匹配当前版本的 beta 标记更显眼。
+	if fp.AnthropicBeta != "" {
+		setHeader("Anthropic-Beta", fp.AnthropicBeta)
+	}
+
+	// Claude Code 客户端必需的额外头
+	setHeader("X-Stainless-Retry-Count", strconv.Itoa(attempt))
+	setHeader("X-Stainless-Timeout", "60")
+	setHeader("X-App", "cli")
+	setHeader("Anthropic-Dangerous-Direct-Browser-Access", "true")
+}
+
+// requiredAppliedHeaders 是真实 Claude Code 客户端请求中必须出现的完整头集合，
+// 供 VerifyAppliedHeaders 校验。其中 X-Stainless-* 系列头仅在对应 Fingerprint 字段
+// 非空时才会被 ApplyFingerprintWithRetry 设置，若指纹本身某字段为空（如迁移前的旧数据），
+// 应用后就会缺失该头，产生一个不完整的伪装请求，因此这里也一并作为必需项校验。
+var requiredAppliedHeaders = []string{
+	"user-agent",
+	"X-Stainless-Lang",
+	"X-Stainless-Package-Version",
+	"X-Stainless-OS",
+	"X-Stainless-Arch",
+	"X-Stainless-Runtime",
+	"X-Stainless-Runtime-Version",
+	"X-Stainless-Retry-Count",
+	"X-Stainless-Timeout",
+	"X-App",
+	"Anthropic-Dangerous-Direct-Browser-Access",
+}
+
+// VerifyAppliedHeaders 检查 req 是否包含 ApplyFingerprintWithRetry 应当设置的全部头，
+// 返回缺失的头名称列表（即 requiredAppliedHeaders 中的原始写法，未做规范化）；返回 nil 表示头集合完整。
+// 用于中继层在转发前拒绝一个应用不完整的伪装请求（例如指纹某字段为空导致对应头被跳过，
+// 或指纹为 nil 时 ApplyFingerprint 直接返回、不设置任何头）。
+func VerifyAppliedHeaders(req *http.Request) []string {
+	var missing []string
+	for _, name := range requiredAppliedHeaders {
+		if req.Header.Get(name) == "" {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// fingerprintOverrideXxxHeader 是请求级指纹覆盖使用的可信内部头名称，供运维/排查时强制
+// 某次请求使用特定平台组合，而不必为此单独调整账号的持久化指纹。
+const (
+	fingerprintOverrideSecretHeader         = "X-Sub2API-Force-Secret"
+	fingerprintOverrideUAHeader             = "X-Sub2API-Force-UA"
+	fingerprintOverrideOSHeader             = "X-Sub2API-Force-OS"
+	fingerprintOverrideArchHeader           = "X-Sub2API-Force-Arch"
+	fingerprintOverrideRuntimeHeader        = "X-Sub2API-Force-Runtime"
+	fingerprintOverrideRuntimeVersionHeader = "X-Sub2API-Force-Runtime-Version"
+)
+
+// fingerprintOverrideHeaders 列出全部指纹覆盖相关头，供 stripFingerprintOverrideHeaders
+// 在转发前统一清除，避免这些内部调试头透传给上游。
+var fingerprintOverrideHeaders = []string{
+	fingerprintOverrideSecretHeader,
+	fingerprintOverrideUAHeader,
+	fingerprintOverrideOSHeader,
+	fingerprintOverrideArchHeader,
+	fingerprintOverrideRuntimeHeader,
+	fingerprintOverrideRuntimeVersionHeader,
+}
+
+// ParseFingerprintOverride 解析 headers 中的可信指纹覆盖头，返回一个仅包含被覆盖字段的
+// Fingerprint 补丁，供调用方与账号原有指纹合并（见 MergeFingerprintOverride）。
+// 仅当 WithFingerprintOverrideSecret 已配置密钥，且 X-Sub2API-Force-Secret 头与之常数时间
+// 比较匹配时才生效；密钥未配置、请求未携带或不匹配时返回 nil，其余覆盖头被忽略。
+func (s *IdentityService) ParseFingerprintOverride(headers http.Header) *Fingerprint {
+	if s.overrideSecret == "" {
+		return nil
+	}
+	provided := headers.Get(fingerprintOverrideSecretHeader)
+	if provided == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(s.overrideSecret)) != 1 {
+		return nil
+	}
+
+	return &Fingerprint{
+		UserAgent:               headers.Get(fingerprintOverrideUAHeader),
+		StainlessOS:             headers.Get(fingerprintOverrideOSHeader),
+		StainlessArch:           headers.Get(fingerprintOverrideArchHeader),
+		StainlessRuntime:        headers.Get(fingerprintOverrideRuntimeHeader),
+		StainlessRuntimeVersion: headers.Get(fingerprintOverrideRuntimeVersionHeader),
+	}
+}
+
+// MergeFingerprintOverride 返回 base 与 patch 合并后的新指纹：patch 中每个非空字段覆盖 base
+// 对应字段，其余字段保留 base 原值；不修改 base 本身。base 或 patch 为 nil 时原样返回 base。
+func MergeFingerprintOverride(base *Fingerprint, patch *Fingerprint) *Fingerprint {
+	if base == nil || patch == nil {
+		return base
+	}
+	merged := *base
+	if patch.UserAgent != "" {
+		merged.UserAgent = patch.UserAgent
+	}
+	if patch.StainlessOS != "" {
+		merged.StainlessOS = patch.StainlessOS
+	}
+	if patch.StainlessArch != "" {
+		merged.StainlessArch = patch.StainlessArch
+	}
+	if patch.StainlessRuntime != "" {
+		merged.StainlessRuntime = patch.StainlessRuntime
+	}
+	if patch.StainlessRuntimeVersion != "" {
+		merged.StainlessRuntimeVersion = patch.StainlessRuntimeVersion
+	}
+	return &merged
+}
+
+// stripFingerprintOverrideHeaders 从 header 中移除全部 X-Sub2API-Force-* 覆盖头，
+// 供 ApplyFingerprintWithRetry 在转发前调用。
+func stripFingerprintOverrideHeaders(header http.Header) {
+	for _, name := range fingerprintOverrideHeaders {
+		header.Del(name)
+	}
+}
+
+// allowedStainlessHeaders 是 ApplyFingerprint 之后请求头中允许保留的 X-Stainless-* 头
+// （规范化后的 Header 键），即 ApplyFingerprintWithRetry 自己会设置的那一组。真实
+// Claude Code 客户端只发送这些头；如果上游转发的请求里带着额外的 X-Stainless-* 头
+// （例如某些 SDK 自动附带的 X-Stainless-Helper-Method、X-Stainless-Async），会与我们
+// 覆盖写入的指纹形成矛盾，暴露出真实客户端并非 Claude Code，因此需要先清除。
+// 定义为包级变量以便按需扩展。
+var allowedStainlessHeaders = buildAllowedHeaderSet(
+	"X-Stainless-Lang",
+	"X-Stainless-Package-Version",
+	"X-Stainless-OS",
+	"X-Stainless-Arch",
+	"X-Stainless-Runtime",
+	"X-Stainless-Runtime-Version",
+	"X-Stainless-Retry-Count",
+	"X-Stainless-Timeout",
+)
+
+func buildAllowedHeaderSet(names ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+	return set
+}
+
+// scrubUnknownStainlessHeaders 删除 header 中所有不在 allowedStainlessHeaders 白名单内的
+// X-Stainless-* 头，供 ApplyFingerprintWithRetry 在覆盖写入自己的指纹前调用。
+func scrubUnknownStainlessHeaders(header http.Header) {
+	for key := range header {
+		if !strings.HasPrefix(key, "X-Stainless-") {
+			continue
+		}
+		if _, ok := allowedStainlessHeaders[key]; !ok {
+			header.Del(key)
+		}
+	}
+}
+
+// StainlessHeaders 是从请求头中读取出的 x-stainless-* 值，字段名与 Fingerprint 的
+// Stainless* 字段一一对应，便于将来需要读取（而非仅写入）客户端上报的 Stainless 信息时使用。
+type StainlessHeaders struct {
+	Lang           string
+	PackageVersion string
+	OS             string
+	Arch           string
+	Runtime        string
+	RuntimeVersion string
+}
+
+// dedupeStainlessHeaderValues 从同一个 header key 的多个值中选出用于填充的单一值：
+// 值全部相同（客户端重复携带同一个头，如某些中间代理导致的合并/拆分）时直接返回该值；
+// 出现不同取值（客户端异常携带多个互相矛盾的 Stainless 头）时记录警告并回退到第一个值，
+// 避免 fill-missing 模式采信一个自相矛盾的信号。
+func dedupeStainlessHeaderValues(key string, values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	first := values[0]
+	for _, v := range values[1:] {
+		if v != first {
+			log.Printf("Warning: request carries duplicate %s header with conflicting values %v, using first: %q", key, values, first)
+			break
+		}
+	}
+	return first
+}
+
+// canonicalizeStainlessHeaders 从 h 中大小写不敏感地读取 x-stainless-* 头。
+// http.Header 本身按 MIME 头规范化后的键（如 "X-Stainless-Os"）存储，标准的 Get/Set
+// 已能正确处理常见大小写变体，但当调用方手工构造 map（如测试、或从其他协议透传）时，
+// 键可能未被规范化，此时 Header.Get 会查找失败。这里显式按不区分大小写的方式匹配键名，
+// 以便未来信任客户端上报的 Stainless 值（fill-missing 模式）时不会因大小写差异漏读。
+func canonicalizeStainlessHeaders(h http.Header) StainlessHeaders {
+	var out StainlessHeaders
+	for key, values := range h {
+		if len(values) == 0 {
+			continue
+		}
+		value := dedupeStainlessHeaderValues(key, values)
+		switch strings.ToLower(key) {
+		case "x-stainless-lang":
+			out.Lang = value
+		case "x-stainless-package-version":
+			out.PackageVersion = value
+		case "x-stainless-os":
+			out.OS = value
+		case "x-stainless-arch":
+			out.Arch = value
+		case "x-stainless-runtime":
+			out.Runtime = value
+		case "x-stainless-runtime-version":
+			out.RuntimeVersion = value
+		}
+	}
+	return out
+}
+
+// DetectPlatformInconsistency 比较请求中客户端自带的平台相关头（x-stainless-os/arch 及
+// User-Agent 中的平台信息）与即将套用的指纹 fp，返回发现的不一致描述列表；无不一致时返回 nil。
+// 供调用方在 ApplyFingerprint 覆盖这些头之前记录或拒绝，而不是让不一致的信号被静默覆盖掉——
+// 客户端自带的 OS/Arch 与我们即将下发的指纹不符，往往意味着请求体里可能还带着与该客户端平台
+// 相关但我们没有改写的信息（如 Windows 风格路径），仅覆盖头本身并不能消除这种关联性。
+func DetectPlatformInconsistency(req *http.Request, fp *Fingerprint) []string {
+	if req == nil || fp == nil {
+		return nil
+	}
+
+	incoming := canonicalizeStainlessHeaders(req.Header)
+	var issues []string
+
+	if incoming.OS != "" && fp.StainlessOS != "" && !strings.EqualFold(incoming.OS, fp.StainlessOS) {
+		issues = append(issues, fmt.Sprintf("x-stainless-os mismatch: client sent %q, fingerprint is %q", incoming.OS, fp.StainlessOS))
+	}
+	if incoming.Arch != "" && fp.StainlessArch != "" && !strings.EqualFold(incoming.Arch, fp.StainlessArch) {
+		issues = append(issues, fmt.Sprintf("x-stainless-arch mismatch: client sent %q, fingerprint is %q", incoming.Arch, fp.StainlessArch))
+	}
+	if incoming.Runtime != "" && fp.StainlessRuntime != "" && !strings.EqualFold(incoming.Runtime, fp.StainlessRuntime) {
+		issues = append(issues, fmt.Sprintf("x-stainless-runtime mismatch: client sent %q, fingerprint is %q", incoming.Runtime, fp.StainlessRuntime))
+	}
+
+	if ua := req.Header.Get("User-Agent"); ua != "" && fp.StainlessOS != "" {
+		if platform := platformHintFromUserAgent(ua); platform != "" && !strings.EqualFold(platform, fp.StainlessOS) {
+			issues = append(issues, fmt.Sprintf("user-agent platform mismatch: %q implies %q, fingerprint is %q", ua, platform, fp.StainlessOS))
+		}
+	}
+
+	return issues
+}
+
+// CaptureFingerprint 从一个真实的 Claude Code 请求中提取 User-Agent 与 x-stainless-* 头，
+// 构造出一份可作为参考指纹保存的 Fingerprint（供运维手动核对/另存为账号或默认指纹配置）。
+// req 为空、或其 User-Agent 不匹配 claudeCodeUARegex 时返回 nil——被伪装或代理修改过的
+// User-Agent 不应作为"真实客户端"样本被保存。
+func CaptureFingerprint(req *http.Request) *Fingerprint {
+	if req == nil {
+		return nil
+	}
+
+	ua := req.Header.Get("User-Agent")
+	if !isClaudeCodeUserAgent(ua) {
+		return nil
+	}
+
+	headers := canonicalizeStainlessHeaders(req.Header)
+	return &Fingerprint{
+		UserAgent:               ua,
+		StainlessLang:           headers.Lang,
+		StainlessPackageVersion: headers.PackageVersion,
+		StainlessOS:             headers.OS,
+		StainlessArch:           headers.Arch,
+		StainlessRuntime:        headers.Runtime,
+		StainlessRuntimeVersion: headers.RuntimeVersion,
+		CreatedAt:               time.Now(),
+	}
+}
+
+// platformHintFromUserAgent 从 User-Agent 字符串中粗略提取平台名称（Windows/Darwin/Linux），
+// 用户代理未提及已知平台关键字时返回空字符串
+func platformHintFromUserAgent(ua string) string {
+	lower := strings.ToLower(ua)
+	switch {
+	case strings.Contains(lower, "windows"):
+		return "Windows"
+	case strings.Contains(lower, "mac os"), strings.Contains(lower, "macos"), strings.Contains(lower, "darwin"):
+		return "Darwin"
+	case strings.Contains(lower, "linux"):
+		return "Linux"
+	default:
+		return ""
+	}
+}
+
+// ParseRewrittenUserID 将 RewriteUserID 生成的 user_id 解析为其组成部分：
+// clientID、accountUUID、sessionHash。userID 不匹配重写后格式时 ok 为 false。
+func ParseRewrittenUserID(userID string) (clientID, accountUUID, sessionHash string, ok bool) {
+	matches := rewrittenUserIDRegex.FindStringSubmatch(userID)
+	if matches == nil {
+		return "", "", "", false
+	}
+	return matches[1], matches[2], matches[3], true
+}
+
+// IsUserIDRewritten 判断 body 的 metadata.user_id 是否已经是 RewriteUserID 的输出格式
+// （`user_{clientID}_account_{accountUUID}_session_{sessionHash}`），供调用方在转发前判断
+// 是否需要再次重写，避免对已重写过的请求重复处理。body 不是合法 JSON、缺少 metadata 或
+// user_id 字段时返回 false。
+func IsUserIDRewritten(body []byte) bool {
+	if !gjson.ValidBytes(body) {
+		return false
+	}
+	userIDResult := gjson.GetBytes(body, "metadata.user_id")
+	if userIDResult.Type != gjson.String {
+		return false
+	}
+	_, _, _, ok := ParseRewrittenUserID(userIDResult.String())
+	return ok
+}
+
+// RewrittenUserIDComponents 是重写后 user_id 的结构化表示，供调用方在无需重新解析字符串的情况下使用
+type RewrittenUserIDComponents struct {
+	ClientID    string
+	AccountUUID string
+	SessionHash string
+}
+
+// computeRewrittenUserID 依次尝试 s.userIDPatterns，找到第一个匹配 userID 的格式后，
+// 从其 session_tail 捕获组重新派生确定性的 session hash 并返回重写后的值。
+// 输出格式恒为：user_{cachedClientID}_account_{accountUUID}_session_{newHash}
+//
+// 由于 defaultUserIDPatterns 中覆盖新客户端格式的模式与本方法自身的输出格式同形，
+// 已经是输出格式的 userID 也会命中并被重新计算——保证 session hash 始终由当前
+// accountID 确定性派生，而不是原样保留调用方传入的哈希。
+// preserveSession 为 true 时跳过 SHA256(accountID::sessionTail) 派生，原样保留 sessionTail
+// 作为输出的 session 段，供 RewriteUserIDWithOptions 在需要按原始会话 UUID 追踪上游请求时使用。
+func (s *IdentityService) computeRewrittenUserID(userID string, accountID int64, accountUUID, cachedClientID string, preserveSession bool) (string, bool) {
+	if userID == "" {
+		return "", false
+	}
+
+	for _, pattern := range s.userIDPatterns {
+		matches := pattern.FindStringSubmatch(userID)
+		if matches == nil {
+			continue
+		}
+		idx := pattern.SubexpIndex("session_tail")
+		if idx < 0 || idx >= len(matches) || matches[idx] == "" {
+			continue
+		}
+		sessionTail := matches[idx]
+
+		newSession := sessionTail
+		if !preserveSession {
+			// 生成新的session hash: sessionHasher(SHA256种子(accountID::sessionTail[::salt]))
+			newSession = s.sessionHasher(sessionHashSeed(accountID, sessionTail, s.sessionSalt))
+		}
+
+		return fmt.Sprintf("user_%s_account_%s_session_%s", cachedClientID, accountUUID, newSession), true
+	}
+
+	return "", false
+}
+
+// recordUserIDMapping 在开启映射记录时，写入重写前后的 user_id 映射
+func (s *IdentityService) recordUserIDMapping(accountID int64, newUserID, originalUserID string) {
+	if s.mappingStore == nil {
+		return
+	}
+	mapCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.mappingStore.RecordUserIDMapping(mapCtx, newUserID, originalUserID, s.mappingTTL); err != nil {
+		log.Printf("Warning: failed to record user_id mapping for account %d: %v", accountID, err)
+	}
+}
+
+// RewriteOptions 控制 RewriteUserIDWithOptions 的重写行为
+type RewriteOptions struct {
+	// PreserveSession 为 true 时保留原始 session UUID 不变，仅重写 clientID 与 accountUUID 段；
+	// 默认（false）沿用 RewriteUserID 的行为，按 accountID 派生新的确定性 session hash。
+	// 用于排查上游关联问题时需要按原始会话 UUID 跟踪某个请求。
+	PreserveSession bool
+
+	// RewriteTopLevelUser 为 true 且 metadata.user_id 缺失时，额外尝试重写顶层 user 字符串
+	// 字段（OpenAI 兼容请求形态）。默认 false：并非所有把普通字符串放进 user 字段的客户端
+	// 都是我们要处理的对象，误重写会破坏其原始语义，因此需要显式开启。
+	RewriteTopLevelUser bool
+}
+
+// RewriteUserID 重写body中的metadata.user_id，行为等价于 RewriteUserIDWithOptions(..., RewriteOptions{})。
+func (s *IdentityService) RewriteUserID(body []byte, accountID int64, accountUUID, cachedClientID string) ([]byte, error) {
+	return s.RewriteUserIDWithOptions(body, accountID, accountUUID, cachedClientID, RewriteOptions{})
+}
+
+// RewriteUserIDWithOptions 与 RewriteUserID 行为一致，但可通过 opts 定制重写方式（见 RewriteOptions）。
+// 实现委托给 RewriteUserIDWithResult，丢弃其中的 Rewritten/Reason，仅保留 body。
+func (s *IdentityService) RewriteUserIDWithOptions(body []byte, accountID int64, accountUUID, cachedClientID string, opts RewriteOptions) ([]byte, error) {
+	result, err := s.RewriteUserIDWithResult(body, accountID, accountUUID, cachedClientID, opts)
+	return result.Body, err
+}
+
+// RewriteUserIDResult 是 RewriteUserIDWithResult 的返回值。Rewritten 为 false 时 Body 与
+// 输入 body 相同，Reason 说明具体原因，取值见 RewriteReasonXxx 常量；Rewritten 为 true 时
+// Reason 恒为空字符串（无需区分走的是 fast path 还是 map 版 slow path）。
+type RewriteUserIDResult struct {
+	Body      []byte
+	Rewritten bool
+	Reason    string
+}
+
+// RewriteUserIDWithResult 未发生重写时 Reason 的可能取值。
+const (
+	RewriteReasonNotJSON         = "not-json"
+	RewriteReasonNoMetadata      = "no-metadata"
+	RewriteReasonNoUserID        = "no-user-id"
+	RewriteReasonPatternMismatch = "pattern-mismatch"
+)
+
+// RewriteUserIDWithResult 与 RewriteUserIDWithOptions 行为一致，但返回结构化结果而非仅
+// body，标明是否实际发生了重写、以及未重写的具体原因，供调用方统计/告警重写命中率，
+// 而不必反过来 diff body 才能知道有没有生效。
+//
+// 性能优化：优先走 fast path，用 gjson/sjson 做定点读取与替换，避免将整个 body
+// Unmarshal 成 map[string]any 再完整 Marshal 回去（高 QPS 下这是明显的 GC 压力来源）。
+// fast path 无法处理时（如 sjson 写入失败），回退到基于 map 的 slow path，
+// 两条路径对相同输入产生等价的 JSON 结果。
+func (s *IdentityService) RewriteUserIDWithResult(body []byte, accountID int64, accountUUID, cachedClientID string, opts RewriteOptions) (RewriteUserIDResult, error) {
+	_, span := s.tracer.StartSpan(context.Background(), "identity.RewriteUserID")
+	defer span.End()
+	span.SetAttribute("account_id", accountID)
+
+	if len(body) == 0 || accountUUID == "" || cachedClientID == "" || !gjson.ValidBytes(body) {
+		return RewriteUserIDResult{Body: body, Reason: RewriteReasonNotJSON}, nil
+	}
+
+	metadata := gjson.GetBytes(body, "metadata")
+	if metadata.IsObject() {
+		if userIDResult := metadata.Get("user_id"); userIDResult.Type == gjson.String && userIDResult.String() != "" {
+			return s.rewriteUserIDAtField(body, "metadata.user_id", userIDResult.String(), accountID, accountUUID, cachedClientID, opts)
+		}
+	}
+
+	if opts.RewriteTopLevelUser {
+		if userResult := gjson.GetBytes(body, "user"); userResult.Type == gjson.String && userResult.String() != "" {
+			return s.rewriteUserIDAtField(body, "user", userResult.String(), accountID, accountUUID, cachedClientID, opts)
+		}
+	}
+
+	if !metadata.IsObject() {
+		return RewriteUserIDResult{Body: body, Reason: RewriteReasonNoMetadata}, nil
+	}
+	return RewriteUserIDResult{Body: body, Reason: RewriteReasonNoUserID}, nil
+}
+
+// rewriteUserIDAtField 对 body 中 field 指向的字符串字段（gjson/sjson 路径，如
+// "metadata.user_id" 或顶层 "user"）应用 computeRewrittenUserID，供 RewriteUserIDWithResult
+// 在定位到候选 user_id 字符串后统一完成匹配、写回与映射记录。
+func (s *IdentityService) rewriteUserIDAtField(body []byte, field, userID string, accountID int64, accountUUID, cachedClientID string, opts RewriteOptions) (RewriteUserIDResult, error) {
+	newUserID, ok := s.computeRewrittenUserID(userID, accountID, accountUUID, cachedClientID, opts.PreserveSession)
+	if !ok {
+		return RewriteUserIDResult{Body: body, Reason: RewriteReasonPatternMismatch}, nil
+	}
+
+	rewritten, err := sjson.SetBytes(body, field, newUserID)
+	if err != nil {
+		mapped, mapErr := s.rewriteFieldViaMap(body, field, newUserID)
+		if mapErr != nil {
+			return RewriteUserIDResult{}, mapErr
+		}
+		s.recordUserIDMapping(accountID, newUserID, userID)
+		return RewriteUserIDResult{Body: mapped, Rewritten: true}, nil
+	}
+
+	s.recordUserIDMapping(accountID, newUserID, userID)
+	debugLogAccount(accountID, "rewrote %s %s -> %s", field, redactIdentifier(userID), redactIdentifier(newUserID))
+	return RewriteUserIDResult{Body: rewritten, Rewritten: true}, nil
+}
+
+// RewriteUserIDDetailed 与 RewriteUserID 行为一致，并在确实发生重写时额外返回重写后
+// user_id 的结构化组成部分，避免调用方为拿到 clientID/accountUUID/sessionHash 而重新解析字符串。
+// 未发生重写（不含匹配的 user_id、或重写失败）时 ok 为 false，components 为零值。
+func (s *IdentityService) RewriteUserIDDetailed(body []byte, accountID int64, accountUUID, cachedClientID string) (rewritten []byte, components RewrittenUserIDComponents, ok bool, err error) {
+	rewritten, err = s.RewriteUserID(body, accountID, accountUUID, cachedClientID)
+	if err != nil {
+		return rewritten, RewrittenUserIDComponents{}, false, err
+	}
+
+	newUserIDResult := gjson.GetBytes(rewritten, "metadata.user_id")
+	if newUserIDResult.Type != gjson.String {
+		return rewritten, RewrittenUserIDComponents{}, false, nil
+	}
+
+	clientID, parsedAccountUUID, sessionHash, matched := ParseRewrittenUserID(newUserIDResult.String())
+	if !matched {
+		return rewritten, RewrittenUserIDComponents{}, false, nil
+	}
+
+	return rewritten, RewrittenUserIDComponents{ClientID: clientID, AccountUUID: parsedAccountUUID, SessionHash: sessionHash}, true, nil
+}
+
+// rewriteUserIDViaMap 是 RewriteUserID 的 map 版慢路径实现，作为 fast path 的回退，
+// 也用于基准测试和一致性测试中与 fast path 的行为进行比对。
+func (s *IdentityService) rewriteUserIDViaMap(body []byte, accountID int64, accountUUID, cachedClientID string, preserveSession bool) ([]byte, error) {
+	var reqMap map[string]any
+	if err := json.Unmarshal(body, &reqMap); err != nil {
+		return body, nil
+	}
+
+	metadata, ok := reqMap["metadata"].(map[string]any)
+	if !ok {
+		return body, nil
+	}
+
+	userID, ok := metadata["user_id"].(string)
+	if !ok || userID == "" {
+		return body, nil
+	}
+
+	newUserID, ok := s.computeRewrittenUserID(userID, accountID, accountUUID, cachedClientID, preserveSession)
+	if !ok {
+		return body, nil
+	}
+
+	metadata["user_id"] = newUserID
+	reqMap["metadata"] = metadata
+
+	s.recordUserIDMapping(accountID, newUserID, userID)
+
+	return json.Marshal(reqMap)
+}
+
+// rewriteFieldViaMap 是 rewriteUserIDAtField 的 map 版慢路径回退：field 已定位、newValue
+// 已算好，仅负责把它写回 body 对应的字段（"metadata.user_id" 或顶层 "user"）。
+// 与 rewriteUserIDViaMap 不同，这里不重新计算 newValue，也不做映射记录（由调用方处理）。
+func (s *IdentityService) rewriteFieldViaMap(body []byte, field, newValue string) ([]byte, error) {
+	var reqMap map[string]any
+	if err := json.Unmarshal(body, &reqMap); err != nil {
+		return body, nil
+	}
+
+	switch field {
+	case "metadata.user_id":
+		metadata, ok := reqMap["metadata"].(map[string]any)
+		if !ok {
+			return body, nil
+		}
+		metadata["user_id"] = newValue
+		reqMap["metadata"] = metadata
+	case "user":
+		reqMap["user"] = newValue
+	default:
+		return body, nil
+	}
+
+	return json.Marshal(reqMap)
+}
+
+// LookupOriginalUserID 返回重写后 user_id 对应的原始 user_id，用于日志排查。
+// 若未开启映射记录（EnableUserIDMapping 未调用）或映射不存在，返回空字符串。
+func (s *IdentityService) LookupOriginalUserID(ctx context.Context, rewrittenUserID string) (string, error) {
+	if s.mappingStore == nil {
+		return "", nil
+	}
+	return s.mappingStore.LookupOriginalUserID(ctx, rewrittenUserID)
+}
+
+// ErrMetadataNotObject 表示请求体中的 metadata 字段存在但不是 JSON 对象
+var ErrMetadataNotObject = errors.New("metadata field is present but is not a JSON object")
+
+// RewriteUserIDStrict 与 RewriteUserID 行为一致，但当 metadata 字段存在且不是 JSON 对象时
+// （例如客户端发送了字符串或数组），返回 ErrMetadataNotObject 而不是静默跳过，
+// 便于运营方发现发送畸形请求的客户端。
+func (s *IdentityService) RewriteUserIDStrict(body []byte, accountID int64, accountUUID, cachedClientID string) ([]byte, error) {
+	if len(body) == 0 || accountUUID == "" || cachedClientID == "" {
+		return body, nil
+	}
+
+	var reqMap map[string]any
+	if err := json.Unmarshal(body, &reqMap); err != nil {
+		return body, nil
+	}
+
+	if rawMetadata, present := reqMap["metadata"]; present {
+		if _, ok := rawMetadata.(map[string]any); !ok {
+			return nil, ErrMetadataNotObject
+		}
+	}
+
+	return s.RewriteUserID(body, accountID, accountUUID, cachedClientID)
+}
+
+// ComputeSessionHash 计算 RewriteUserID 会为给定 accountID/sessionTail 组合生成的会话哈希，
+// 使用与 RewriteUserID 相同的 "accountID::sessionTail" 种子格式，不考虑实例级 salt
+// （即等价于未配置 WithSessionSalt 时的输出）。供外部工具在不执行重写的情况下
+// 预测/校验重写后的会话哈希是否一致。配置了 WithSessionSalt 的实例应改用
+// (*IdentityService).ComputeSessionHash。
+func ComputeSessionHash(accountID int64, sessionTail string) string {
+	return generateUUIDFromSeed(sessionHashSeed(accountID, sessionTail, ""))
+}
+
+// ComputeSessionHash 与包级函数 ComputeSessionHash 相同，但会混入本实例通过
+// WithSessionSalt 配置的盐值，并使用本实例通过 WithSessionHasher 配置的输出格式，
+// 供已启用实例级 salt/自定义 session hash 格式的部署预测/校验重写后的会话哈希。
+func (s *IdentityService) ComputeSessionHash(accountID int64, sessionTail string) string {
+	return s.sessionHasher(sessionHashSeed(accountID, sessionTail, s.sessionSalt))
+}
+
+// entropySource 是 generateClientID 系列函数读取随机字节的来源，默认为 crypto/rand.Reader。
+// 测试可替换为始终出错的 io.Reader 以演练熵源耗尽时的 fallback/严格模式路径。
+var entropySource io.Reader = rand.Reader
+
+// clientIDFallbackCounter 在 crypto/rand 持续失败时为每次调用提供一个单调递增的区分因子，
+// 避免同一纳秒内多次 fallback 生成相同 ID。
+var clientIDFallbackCounter atomic.Uint64
+
+// generateClientID 生成64位十六进制客户端ID（32字节随机数），fallback 时不区分账号
+func generateClientID() string {
+	return generateClientIDForAccount(0)
+}
+
+// generateClientIDForAccount 与 generateClientID 相同，但 crypto/rand 失败时，fallback
+// 摘要额外混入 accountID，降低不同账号在熵源耗尽期间撞出相同 ID 的概率。
+func generateClientIDForAccount(accountID int64) string {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(entropySource, b); err != nil {
+		log.Printf("Warning: crypto/rand.Read failed: %v, using fallback", err)
+		return fallbackClientID(accountID)
+	}
+	return hex.EncodeToString(b)
+}
+
+// generateClientIDStrict 与 generateClientIDForAccount 相同，但 crypto/rand 失败时不使用
+// 可预测的 fallback，而是直接返回错误，供要求高熵保证的调用方选用。
+func generateClientIDStrict(accountID int64) (string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(entropySource, b); err != nil {
+		return "", fmt.Errorf("generate client id: entropy source failed: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// fallbackClientID 在 crypto/rand 不可用时，混合时间戳、进程 PID、单调计数器与 accountID
+// 生成一个不易预测、同一进程内不会重复的 ID；仍弱于真随机数，仅用于极端降级场景。
+func fallbackClientID(accountID int64) string {
+	counter := clientIDFallbackCounter.Add(1)
+	seed := fmt.Sprintf("%d:%d:%d:%d", time.Now().UnixNano(), os.Getpid(), counter, accountID)
+	h := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(h[:])
+}
+
+// minClientIDLength 是 hasSufficientEntropy 接受的最短 ClientID 长度；短于此长度直接判定为低熵。
+const minClientIDLength = 16
+
+// hasSufficientEntropy 粗略检测 clientID 是否具备足够的随机性，用于拦截手工设置或历史迁移
+// 数据中明显退化的值（全同字符、逐字符递增/递减等有规律的十六进制串）——而不是重新实现真正
+// 的统计熵检验，generateClientID 系列函数本身已使用 crypto/rand 保证真随机来源。
+func hasSufficientEntropy(clientID string) bool {
+	if len(clientID) < minClientIDLength {
+		return false
+	}
+
+	b := []byte(clientID)
+	distinct := make(map[byte]struct{}, len(b))
+	ascending, descending := true, true
+	for i, c := range b {
+		distinct[c] = struct{}{}
+		if i > 0 && int(c)-int(b[i-1]) != 1 {
+			ascending = false
+		}
+		if i > 0 && int(b[i-1])-int(c) != 1 {
+			descending = false
+		}
+	}
+	if ascending || descending {
+		return false
+	}
+	return len(distinct) >= 4
+}
+
+// generateUUIDFromSeed 从种子生成确定性UUID v4格式字符串
+func generateUUIDFromSeed(seed string) string {
+	hash := sha256.Sum256([]byte(seed))
+	bytes := hash[:16]
+
+	// 设置UUID v4版本和变体位
 	bytes[6] = (bytes[6] & 0x0f) | 0x40
 	bytes[8] = (bytes[8] & 0x3f) | 0x80
 
@@ -231,36 +1791,101 @@ func generateUUIDFromSeed(seed string) string {
 		bytes[0:4], bytes[4:6], bytes[6:8], bytes[8:10], bytes[10:16])
 }
 
+// SessionHasher 从种子字符串确定性地派生 RewriteUserID/ComputeSessionHash 输出的 session
+// hash 段，供 WithSessionHasher 适配期望不同会话标识符形状的上游网关。
+type SessionHasher func(seed string) string
+
+// UUIDv4SessionHasher 是默认的 SessionHasher，输出与未配置 WithSessionHasher 时完全一致的
+// UUID v4 形状字符串（与真实客户端自身生成的 session UUID 同形）。
+func UUIDv4SessionHasher(seed string) string {
+	return generateUUIDFromSeed(seed)
+}
+
+// Hex32SessionHasher 输出不带分隔符的 32 位十六进制字符串（种子 SHA256 摘要的前 16 字节），
+// 供期望紧凑十六进制会话 ID 而非 UUID 形状的上游网关使用。
+//
+// 注意：defaultUserIDPatterns 要求 session_tail 段是 36 字符的 UUID 形状，配合该 hasher 使用时
+// RewriteUserID 输出的 user_id 无法被 defaultUserIDPatterns 重新匹配（不影响首次重写，
+// 但意味着该输出不是幂等的重写起点）；如需再次重写，调用方需自行提供匹配的 WithUserIDPatterns。
+func Hex32SessionHasher(seed string) string {
+	hash := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(hash[:16])
+}
+
+// NewPrefixedSessionHasher 返回一个输出 "{prefix}{32位十六进制}" 的 SessionHasher，供需要在
+// session 标识符中携带自定义前缀（如部署/网关名）的场景使用。prefix 为空时等价于 Hex32SessionHasher。
+func NewPrefixedSessionHasher(prefix string) SessionHasher {
+	return func(seed string) string {
+		return prefix + Hex32SessionHasher(seed)
+	}
+}
+
 // isClaudeCodeUserAgent 检查User-Agent是否为Claude Code客户端格式
 func isClaudeCodeUserAgent(ua string) bool {
 	return claudeCodeUARegex.MatchString(ua)
 }
 
-// isNewerClaudeCodeVersion 比较两个Claude Code User-Agent版本
-// 返回true如果newUA版本比oldUA更新
-func isNewerClaudeCodeVersion(newUA, oldUA string) bool {
-	newMatches := claudeCodeUARegex.FindStringSubmatch(newUA)
-	oldMatches := claudeCodeUARegex.FindStringSubmatch(oldUA)
-
-	if newMatches == nil || oldMatches == nil {
-		return false
+// CompareClaudeCodeVersion 比较两个 claude-cli User-Agent 的版本号：
+// uaA 更新返回 (1, true)，更旧返回 (-1, true)，相等返回 (0, true)。
+// 只要有一个 UA 不匹配 claudeCodeUARegex，返回 (0, false)——调用方不应据此比较，
+// 避免把畸形 UA 当成版本 0.0.0 参与比较。用于在客户端版本降级时记录日志/告警。
+//
+// 支持第四位数字段（如 2.0.62.4）和预发布后缀（如 2.0.62-beta.1）：四位数字按位比较，
+// 正式版本（无预发布后缀）视为比同一 x.y.z(.w) 的任意预发布版本更新；两者都带预发布
+// 后缀时按字符串比较作为确定性的 tie-break。构建元数据后缀（如 2.0.62+build.5）按
+// semver 语义不参与比较，仅被正则捕获后丢弃。不分配除正则匹配本身外的额外内存。
+func CompareClaudeCodeVersion(uaA, uaB string) (cmp int, ok bool) {
+	a := claudeCodeUARegex.FindStringSubmatch(uaA)
+	b := claudeCodeUARegex.FindStringSubmatch(uaB)
+	if a == nil || b == nil {
+		return 0, false
 	}
 
-	// 解析版本号 (major.minor.patch)
-	newMajor, _ := strconv.Atoi(newMatches[1])
-	newMinor, _ := strconv.Atoi(newMatches[2])
-	newPatch, _ := strconv.Atoi(newMatches[3])
+	for i := 1; i <= 3; i++ {
+		av, _ := strconv.Atoi(a[i])
+		bv, _ := strconv.Atoi(b[i])
+		if av != bv {
+			if av > bv {
+				return 1, true
+			}
+			return -1, true
+		}
+	}
 
-	oldMajor, _ := strconv.Atoi(oldMatches[1])
-	oldMinor, _ := strconv.Atoi(oldMatches[2])
-	oldPatch, _ := strconv.Atoi(oldMatches[3])
+	// 第四位数字段：缺失视为 0
+	aBuild, bBuild := 0, 0
+	if a[4] != "" {
+		aBuild, _ = strconv.Atoi(a[4])
+	}
+	if b[4] != "" {
+		bBuild, _ = strconv.Atoi(b[4])
+	}
+	if aBuild != bBuild {
+		if aBuild > bBuild {
+			return 1, true
+		}
+		return -1, true
+	}
 
-	// 比较版本号
-	if newMajor != oldMajor {
-		return newMajor > oldMajor
+	// 预发布后缀：正式版本（空后缀）比任意预发布版本更新
+	aPre, bPre := a[5], b[5]
+	if aPre == bPre {
+		return 0, true
 	}
-	if newMinor != oldMinor {
-		return newMinor > oldMinor
+	if aPre == "" {
+		return 1, true
 	}
-	return newPatch > oldPatch
+	if bPre == "" {
+		return -1, true
+	}
+	if aPre > bPre {
+		return 1, true
+	}
+	return -1, true
+}
+
+// isNewerClaudeCodeVersion 判断 newUA 版本是否比 oldUA 更新
+func isNewerClaudeCodeVersion(newUA, oldUA string) bool {
+	cmp, ok := CompareClaudeCodeVersion(newUA, oldUA)
+	return ok && cmp > 0
 }
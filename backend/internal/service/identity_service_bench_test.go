@@ -0,0 +1,52 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const benchRewriteUserIDBody = `{"model":"claude-sonnet-4-20250514","stream":true,"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_11111111-1111-1111-1111-111111111111"},"system":[{"type":"text","text":"You are Claude Code, Anthropic's official CLI for Claude."}],"messages":[{"role":"user","content":"hi"}]}`
+
+func TestRewriteUserID_FastPathMatchesMapPath(t *testing.T) {
+	svc := NewIdentityService(nil)
+	body := []byte(benchRewriteUserIDBody)
+
+	fast, err := svc.RewriteUserID(body, 42, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+
+	slow, err := svc.rewriteUserIDViaMap(body, 42, "account-uuid", "cached-client-id", false)
+	require.NoError(t, err)
+
+	var fastMap, slowMap map[string]any
+	require.NoError(t, json.Unmarshal(fast, &fastMap))
+	require.NoError(t, json.Unmarshal(slow, &slowMap))
+	require.Equal(t, slowMap, fastMap)
+}
+
+func BenchmarkRewriteUserID_FastPath(b *testing.B) {
+	svc := NewIdentityService(nil)
+	body := []byte(benchRewriteUserIDBody)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := svc.RewriteUserID(body, 42, "account-uuid", "cached-client-id"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRewriteUserID_MapPath(b *testing.B) {
+	svc := NewIdentityService(nil)
+	body := []byte(benchRewriteUserIDBody)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := svc.rewriteUserIDViaMap(body, 42, "account-uuid", "cached-client-id", false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
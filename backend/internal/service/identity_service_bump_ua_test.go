@@ -0,0 +1,46 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpDefaultUserAgent_UpdatesOnlyOlderFingerprints(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[1] = &Fingerprint{ClientID: "c1", UserAgent: "claude-cli/1.0.0 (external, cli)"}
+	cache.fingerprints[2] = &Fingerprint{ClientID: "c2", UserAgent: "claude-cli/1.5.0 (external, cli)"}
+	cache.fingerprints[3] = &Fingerprint{ClientID: "c3", UserAgent: "claude-cli/2.0.0 (external, cli)"}
+
+	svc := NewIdentityService(cache)
+	updated, err := svc.BumpDefaultUserAgent(context.Background(), "claude-cli/1.6.0 (external, cli)")
+
+	require.NoError(t, err)
+	require.Equal(t, 2, updated)
+	require.Equal(t, "claude-cli/1.6.0 (external, cli)", cache.fingerprints[1].UserAgent)
+	require.Equal(t, "claude-cli/1.6.0 (external, cli)", cache.fingerprints[2].UserAgent)
+	require.Equal(t, "claude-cli/2.0.0 (external, cli)", cache.fingerprints[3].UserAgent, "newer fingerprints must be left alone")
+}
+
+func TestBumpDefaultUserAgent_InvalidUAReturnsError(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	updated, err := svc.BumpDefaultUserAgent(context.Background(), "not-a-claude-code-ua")
+
+	require.Error(t, err)
+	require.Equal(t, 0, updated)
+}
+
+func TestBumpDefaultUserAgent_EmptyFleetIsNoOp(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	updated, err := svc.BumpDefaultUserAgent(context.Background(), "claude-cli/1.5.0 (external, cli)")
+
+	require.NoError(t, err)
+	require.Equal(t, 0, updated)
+}
@@ -0,0 +1,95 @@
+//go:build unit
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// alwaysFailReader simulates a crypto/rand source that has run out of entropy.
+type alwaysFailReader struct{}
+
+func (alwaysFailReader) Read(p []byte) (int, error) {
+	return 0, errors.New("entropy source exhausted")
+}
+
+func withFailingEntropySource(t *testing.T) {
+	original := entropySource
+	entropySource = alwaysFailReader{}
+	t.Cleanup(func() { entropySource = original })
+}
+
+func TestGenerateClientIDForAccount_FallsBackOnEntropyFailure(t *testing.T) {
+	withFailingEntropySource(t)
+
+	id := generateClientIDForAccount(42)
+
+	require.Len(t, id, 64)
+}
+
+func TestGenerateClientIDForAccount_FallbackDiffersAcrossCalls(t *testing.T) {
+	withFailingEntropySource(t)
+
+	first := generateClientIDForAccount(1)
+	second := generateClientIDForAccount(1)
+
+	require.NotEqual(t, first, second, "fallback IDs must not collide across successive calls")
+}
+
+func TestGenerateClientIDForAccount_FallbackDiffersAcrossAccounts(t *testing.T) {
+	withFailingEntropySource(t)
+
+	clientIDFallbackCounter.Store(0)
+	a := fallbackClientID(1)
+	clientIDFallbackCounter.Store(0)
+	b := fallbackClientID(2)
+
+	require.NotEqual(t, a, b, "fallback ID should be influenced by accountID")
+}
+
+func TestGenerateClientIDStrict_ReturnsErrorOnEntropyFailure(t *testing.T) {
+	withFailingEntropySource(t)
+
+	id, err := generateClientIDStrict(7)
+
+	require.Error(t, err)
+	require.Empty(t, id)
+}
+
+func TestGenerateClientIDStrict_SucceedsWithHealthyEntropySource(t *testing.T) {
+	id, err := generateClientIDStrict(7)
+
+	require.NoError(t, err)
+	require.Len(t, id, 64)
+}
+
+func TestHasSufficientEntropy_AcceptsStrongRandomID(t *testing.T) {
+	id := generateClientIDForAccount(1)
+
+	require.True(t, hasSufficientEntropy(id))
+}
+
+func TestHasSufficientEntropy_RejectsAllZeroID(t *testing.T) {
+	id := "0000000000000000000000000000000000000000000000000000000000000000"
+
+	require.False(t, hasSufficientEntropy(id))
+}
+
+func TestHasSufficientEntropy_RejectsSequentialID(t *testing.T) {
+	id := "abcdefghijklmnop"
+
+	require.False(t, hasSufficientEntropy(id))
+}
+
+func TestHasSufficientEntropy_RejectsLowCharDiversityID(t *testing.T) {
+	id := "0101010101010101010101010101010101010101010101010101010101010101"
+
+	require.False(t, hasSufficientEntropy(id))
+}
+
+func TestHasSufficientEntropy_RejectsTooShortID(t *testing.T) {
+	require.False(t, hasSufficientEntropy("abcd1234"))
+}
@@ -0,0 +1,82 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetOrCreateFingerprint_ConcurrentUpdatesNeverLoseTheNewestVersion fires 100 concurrent
+// requests carrying strictly increasing UA versions for the same account and asserts the
+// cached fingerprint ends up on the newest one, guarding against the lost-update race where
+// two goroutines read the same stale cached value and the write from the older version wins.
+func TestGetOrCreateFingerprint_ConcurrentUpdatesNeverLoseTheNewestVersion(t *testing.T) {
+	svc := NewIdentityService(newFakeIdentityCache())
+
+	const accountID = int64(1)
+	const goroutines = 100
+
+	// 先建立一份初始指纹，避免所有 goroutine 同时命中"缓存不存在"分支。
+	_, err := svc.GetOrCreateFingerprint(context.Background(), accountID, http.Header{})
+	require.NoError(t, err)
+
+	versions := make([]string, goroutines)
+	for i := 0; i < goroutines; i++ {
+		versions[i] = fmt.Sprintf("claude-cli/2.0.%d (external, cli)", i+1)
+	}
+	rand.Shuffle(len(versions), func(i, j int) { versions[i], versions[j] = versions[j], versions[i] })
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for _, ua := range versions {
+		ua := ua
+		go func() {
+			defer wg.Done()
+			headers := http.Header{}
+			headers.Set("User-Agent", ua)
+			_, err := svc.GetOrCreateFingerprint(context.Background(), accountID, headers)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	final, err := svc.GetOrCreateFingerprint(context.Background(), accountID, http.Header{})
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("claude-cli/2.0.%d (external, cli)", goroutines), final.UserAgent)
+}
+
+// TestGetOrCreateFingerprint_ConcurrentMissesAgreeOnOneClientID fires many concurrent requests
+// for an account with no cached fingerprint yet and asserts they all observe the same ClientID,
+// guarding against the stampede where each goroutine generates its own ClientID and the last
+// SetFingerprint call silently discards everyone else's.
+func TestGetOrCreateFingerprint_ConcurrentMissesAgreeOnOneClientID(t *testing.T) {
+	svc := NewIdentityService(newFakeIdentityCache())
+
+	const accountID = int64(2)
+	const goroutines = 50
+
+	clientIDs := make([]string, goroutines)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			fp, err := svc.GetOrCreateFingerprint(context.Background(), accountID, http.Header{})
+			require.NoError(t, err)
+			clientIDs[i] = fp.ClientID
+		}()
+	}
+	wg.Wait()
+
+	for i := 1; i < goroutines; i++ {
+		require.Equal(t, clientIDs[0], clientIDs[i], "all concurrent misses must agree on the same generated ClientID")
+	}
+}
@@ -0,0 +1,83 @@
+//go:build unit
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func captureLogOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	var buf bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(original) })
+
+	fn()
+	return buf.String()
+}
+
+func TestSetAccountDebug_LogsOnlyForEnabledAccount(t *testing.T) {
+	SetAccountDebug(101, true)
+	t.Cleanup(func() { SetAccountDebug(101, false) })
+
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	output := captureLogOutput(t, func() {
+		_, err := svc.GetOrCreateFingerprint(context.Background(), 101, http.Header{})
+		require.NoError(t, err)
+		_, err = svc.GetOrCreateFingerprint(context.Background(), 102, http.Header{})
+		require.NoError(t, err)
+	})
+
+	require.Contains(t, output, "[account debug 101]")
+	require.NotContains(t, output, "[account debug 102]")
+}
+
+func TestSetAccountDebug_DisablingStopsLogging(t *testing.T) {
+	SetAccountDebug(103, true)
+	SetAccountDebug(103, false)
+
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	output := captureLogOutput(t, func() {
+		_, err := svc.GetOrCreateFingerprint(context.Background(), 103, http.Header{})
+		require.NoError(t, err)
+	})
+
+	require.NotContains(t, output, "[account debug 103]")
+}
+
+func TestDebugLogAccount_RedactsIdentifiersInOutput(t *testing.T) {
+	SetAccountDebug(104, true)
+	t.Cleanup(func() { SetAccountDebug(104, false) })
+
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	output := captureLogOutput(t, func() {
+		_, err := svc.GetOrCreateFingerprint(context.Background(), 104, http.Header{})
+		require.NoError(t, err)
+	})
+
+	require.Contains(t, output, "[account debug 104]")
+	require.Contains(t, output, "...", "client_id should be redacted rather than logged in full")
+}
+
+func TestRedactIdentifier_ShortIDIsFullyMasked(t *testing.T) {
+	require.Equal(t, "***", redactIdentifier("short"))
+}
+
+func TestRedactIdentifier_LongIDKeepsPrefixAndSuffix(t *testing.T) {
+	id := "0123456789abcdef"
+	got := redactIdentifier(id)
+	require.Equal(t, "0123...cdef", got)
+}
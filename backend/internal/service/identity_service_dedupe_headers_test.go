@@ -0,0 +1,32 @@
+//go:build unit
+
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalizeStainlessHeaders_DuplicateIdenticalValuesCollapseToOne(t *testing.T) {
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+	req.Header.Add("X-Stainless-OS", "Linux")
+	req.Header.Add("X-Stainless-OS", "Linux")
+
+	out := canonicalizeStainlessHeaders(req.Header)
+	require.Equal(t, "Linux", out.OS)
+}
+
+func TestCanonicalizeStainlessHeaders_DuplicateConflictingValuesUseFirst(t *testing.T) {
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+	req.Header.Add("X-Stainless-Arch", "x64")
+	req.Header.Add("X-Stainless-Arch", "arm64")
+
+	out := canonicalizeStainlessHeaders(req.Header)
+	require.Equal(t, "x64", out.Arch)
+}
+
+func TestDedupeStainlessHeaderValues_EmptyReturnsEmptyString(t *testing.T) {
+	require.Equal(t, "", dedupeStainlessHeaderValues("X-Stainless-OS", nil))
+}
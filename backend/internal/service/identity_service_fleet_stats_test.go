@@ -0,0 +1,95 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetFingerprintStats_VariedVersionsAndAges(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+	now := time.Now()
+
+	// 与默认指纹版本相同
+	cache.fingerprints[1] = &Fingerprint{
+		ClientID:  "c1",
+		UserAgent: svc.defaults.UserAgent,
+		CreatedAt: now.Add(-1 * time.Hour),
+	}
+	// 更旧的版本，更早创建
+	cache.fingerprints[2] = &Fingerprint{
+		ClientID:  "c2",
+		UserAgent: "claude-cli/1.0.0 (external, cli)",
+		CreatedAt: now.Add(-30 * 24 * time.Hour),
+	}
+	// 更新的版本，最近创建
+	cache.fingerprints[3] = &Fingerprint{
+		ClientID:  "c3",
+		UserAgent: "claude-cli/9.9.9 (external, cli)",
+		CreatedAt: now.Add(-1 * time.Minute),
+	}
+	// 迁移前的历史数据，没有 CreatedAt
+	cache.fingerprints[4] = &Fingerprint{
+		ClientID:  "c4",
+		UserAgent: "claude-cli/1.0.0 (external, cli)",
+	}
+
+	stats, err := svc.FleetFingerprintStats(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 4, stats.Total)
+	require.Equal(t, 1, stats.ByUserAgent[svc.defaults.UserAgent])
+	require.Equal(t, 2, stats.ByUserAgent["claude-cli/1.0.0 (external, cli)"])
+	require.Equal(t, 1, stats.ByUserAgent["claude-cli/9.9.9 (external, cli)"])
+	require.Equal(t, 3, stats.NonDefaultCount)
+
+	require.InDelta(t, (30 * 24 * time.Hour).Seconds(), stats.OldestAge.Seconds(), 5)
+	require.InDelta(t, time.Minute.Seconds(), stats.NewestAge.Seconds(), 5)
+}
+
+func TestFleetFingerprintStats_EmptyFleet(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	stats, err := svc.FleetFingerprintStats(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 0, stats.Total)
+	require.Equal(t, 0, stats.NonDefaultCount)
+	require.Zero(t, stats.OldestAge)
+	require.Zero(t, stats.NewestAge)
+}
+
+func TestFleetFingerprintStats_PropagatesCacheError(t *testing.T) {
+	svc := NewIdentityService(&erroringIdentityCache{})
+
+	_, err := svc.FleetFingerprintStats(context.Background())
+
+	require.Error(t, err)
+}
+
+// erroringIdentityCache is an IdentityCache stub whose AllFingerprints always fails, used to
+// verify FleetFingerprintStats surfaces cache enumeration errors instead of masking them.
+type erroringIdentityCache struct{}
+
+func (c *erroringIdentityCache) GetFingerprint(context.Context, int64) (*Fingerprint, error) {
+	return nil, nil
+}
+
+func (c *erroringIdentityCache) SetFingerprint(context.Context, int64, *Fingerprint) error {
+	return nil
+}
+
+func (c *erroringIdentityCache) AllFingerprints(context.Context) (map[int64]*Fingerprint, error) {
+	return nil, errors.New("cache unavailable")
+}
+
+func (c *erroringIdentityCache) DeleteFingerprint(context.Context, int64) error {
+	return nil
+}
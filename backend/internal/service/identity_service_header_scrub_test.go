@@ -0,0 +1,46 @@
+//go:build unit
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFingerprint_ScrubsUnknownStainlessHeaders(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+	req.Header.Set("X-Stainless-Helper-Method", "stream")
+	req.Header.Set("X-Stainless-Async", "false")
+
+	svc := NewIdentityService(nil)
+	svc.ApplyFingerprint(req, &Fingerprint{
+		UserAgent:               "claude-cli/2.0.62 (external, cli)",
+		StainlessLang:           "js",
+		StainlessPackageVersion: "0.1.0",
+		StainlessOS:             "Linux",
+		StainlessArch:           "x64",
+		StainlessRuntime:        "node",
+		StainlessRuntimeVersion: "v22.14.0",
+	})
+
+	require.Empty(t, req.Header.Get("X-Stainless-Helper-Method"))
+	require.Empty(t, req.Header.Get("X-Stainless-Async"))
+	require.Equal(t, "js", req.Header.Get("X-Stainless-Lang"))
+	require.Equal(t, "Linux", req.Header.Get("X-Stainless-OS"))
+}
+
+func TestApplyFingerprint_KeepsKnownStainlessHeaders(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+
+	svc := NewIdentityService(nil)
+	svc.ApplyFingerprintWithRetry(req, &Fingerprint{
+		StainlessOS: "Darwin",
+	}, 2)
+
+	require.Equal(t, "Darwin", req.Header.Get("X-Stainless-OS"))
+	require.Equal(t, "2", req.Header.Get("X-Stainless-Retry-Count"))
+	require.Equal(t, "60", req.Header.Get("X-Stainless-Timeout"))
+}
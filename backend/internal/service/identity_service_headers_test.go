@@ -0,0 +1,229 @@
+//go:build unit
+
+package service
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFingerprint_SetsRetryCountZero(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+
+	svc.ApplyFingerprint(req, &Fingerprint{UserAgent: "claude-cli/1.0.0"})
+
+	require.Equal(t, "0", req.Header.Get("X-Stainless-Retry-Count"))
+}
+
+func TestApplyFingerprintWithRetry_SetsRetryCountToAttempt(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+
+	svc.ApplyFingerprintWithRetry(req, &Fingerprint{UserAgent: "claude-cli/1.0.0"}, 3)
+
+	require.Equal(t, "3", req.Header.Get("X-Stainless-Retry-Count"))
+}
+
+func TestApplyFingerprint_SetsAnthropicBetaWhenPresent(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+
+	svc.ApplyFingerprint(req, &Fingerprint{UserAgent: "claude-cli/1.0.0", AnthropicBeta: "claude-code-20250219"})
+
+	require.Equal(t, "claude-code-20250219", req.Header.Get("Anthropic-Beta"))
+}
+
+func TestApplyFingerprint_PreservesClientAnthropicBetaWhenFieldEmpty(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("Anthropic-Beta", "some-other-beta-flag")
+
+	svc.ApplyFingerprint(req, &Fingerprint{UserAgent: "claude-cli/1.0.0"})
+
+	require.Equal(t, "some-other-beta-flag", req.Header.Get("Anthropic-Beta"))
+}
+
+func TestApplyFingerprint_NoAnthropicBetaHeaderWhenFieldEmptyAndClientSentNone(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+
+	svc.ApplyFingerprint(req, &Fingerprint{UserAgent: "claude-cli/1.0.0"})
+
+	require.Empty(t, req.Header.Get("Anthropic-Beta"))
+}
+
+func TestApplyFingerprintWithOptions_DefaultOverwritesExistingRetryCount(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("X-Stainless-Retry-Count", "7")
+
+	svc.ApplyFingerprintWithOptions(req, &Fingerprint{UserAgent: "claude-cli/1.0.0"}, 2, ApplyOptions{})
+
+	require.Equal(t, "2", req.Header.Get("X-Stainless-Retry-Count"))
+}
+
+func TestApplyFingerprintWithOptions_OnlyIfMissingPreservesExistingRetryCount(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("X-Stainless-Retry-Count", "7")
+
+	svc.ApplyFingerprintWithOptions(req, &Fingerprint{UserAgent: "claude-cli/1.0.0"}, 2, ApplyOptions{OnlyIfMissing: true})
+
+	require.Equal(t, "7", req.Header.Get("X-Stainless-Retry-Count"), "a retry layer's count must not be stomped when OnlyIfMissing is set")
+}
+
+func TestApplyFingerprintWithOptions_OnlyIfMissingStillSetsAbsentHeaders(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+
+	svc.ApplyFingerprintWithOptions(req, &Fingerprint{UserAgent: "claude-cli/1.0.0", StainlessOS: "Linux"}, 2, ApplyOptions{OnlyIfMissing: true})
+
+	require.Equal(t, "claude-cli/1.0.0", req.Header.Get("user-agent"))
+	require.Equal(t, "Linux", req.Header.Get("X-Stainless-OS"))
+	require.Equal(t, "2", req.Header.Get("X-Stainless-Retry-Count"))
+}
+
+func TestApplyFingerprintWithOptions_OnlyIfMissingPreservesDownstreamAppliedHeaders(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("user-agent", "claude-cli/9.9.9")
+	req.Header.Set("X-Stainless-OS", "Windows")
+
+	svc.ApplyFingerprintWithOptions(req, &Fingerprint{UserAgent: "claude-cli/1.0.0", StainlessOS: "Linux"}, 0, ApplyOptions{OnlyIfMissing: true})
+
+	require.Equal(t, "claude-cli/9.9.9", req.Header.Get("user-agent"), "downstream hop already applied a valid user-agent")
+	require.Equal(t, "Windows", req.Header.Get("X-Stainless-OS"))
+}
+
+func TestDetectPlatformInconsistency_FlagsWindowsVsLinuxMismatch(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("X-Stainless-OS", "Windows")
+
+	issues := DetectPlatformInconsistency(req, &Fingerprint{StainlessOS: "Linux"})
+
+	require.Len(t, issues, 1)
+	require.Contains(t, issues[0], "x-stainless-os mismatch")
+	require.Contains(t, issues[0], "Windows")
+	require.Contains(t, issues[0], "Linux")
+}
+
+func TestDetectPlatformInconsistency_FlagsUserAgentPlatformMismatch(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("User-Agent", "SomeClient/1.0 (Windows NT 10.0)")
+
+	issues := DetectPlatformInconsistency(req, &Fingerprint{StainlessOS: "Linux"})
+
+	require.NotEmpty(t, issues)
+	found := false
+	for _, issue := range issues {
+		if strings.Contains(issue, "user-agent platform mismatch") {
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestDetectPlatformInconsistency_NoIssuesWhenConsistent(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("X-Stainless-OS", "Linux")
+	req.Header.Set("X-Stainless-Arch", "x64")
+
+	issues := DetectPlatformInconsistency(req, &Fingerprint{StainlessOS: "Linux", StainlessArch: "x64"})
+
+	require.Empty(t, issues)
+}
+
+func TestDetectPlatformInconsistency_NoClientHintsIsNoOp(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+
+	issues := DetectPlatformInconsistency(req, &Fingerprint{StainlessOS: "Linux"})
+
+	require.Empty(t, issues)
+}
+
+func TestCanonicalizeStainlessHeaders_LowercaseKeys(t *testing.T) {
+	h := http.Header{
+		"x-stainless-lang":            {"js"},
+		"x-stainless-package-version": {"0.52.0"},
+		"x-stainless-os":              {"Linux"},
+		"x-stainless-arch":            {"x64"},
+		"x-stainless-runtime":         {"node"},
+		"x-stainless-runtime-version": {"v22.14.0"},
+	}
+
+	got := canonicalizeStainlessHeaders(h)
+
+	require.Equal(t, StainlessHeaders{
+		Lang:           "js",
+		PackageVersion: "0.52.0",
+		OS:             "Linux",
+		Arch:           "x64",
+		Runtime:        "node",
+		RuntimeVersion: "v22.14.0",
+	}, got)
+}
+
+func TestCanonicalizeStainlessHeaders_MixedCaseKeys(t *testing.T) {
+	h := http.Header{
+		"X-STAINLESS-LANG": {"python"},
+		"x-Stainless-Os":   {"Darwin"},
+	}
+
+	got := canonicalizeStainlessHeaders(h)
+
+	require.Equal(t, "python", got.Lang)
+	require.Equal(t, "Darwin", got.OS)
+	require.Empty(t, got.Arch)
+}
+
+func TestCanonicalizeStainlessHeaders_CanonicalHTTPHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Stainless-Runtime", "bun")
+
+	got := canonicalizeStainlessHeaders(h)
+
+	require.Equal(t, "bun", got.Runtime)
+}
+
+func TestCanonicalizeStainlessHeaders_EmptyHeaderReturnsZeroValue(t *testing.T) {
+	got := canonicalizeStainlessHeaders(http.Header{})
+	require.Equal(t, StainlessHeaders{}, got)
+}
+
+func TestCaptureFingerprint_ExtractsFullClaudeCodeHeaders(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("User-Agent", "claude-cli/2.0.62 (external, cli)")
+	req.Header.Set("X-Stainless-Lang", "js")
+	req.Header.Set("X-Stainless-Package-Version", "0.52.0")
+	req.Header.Set("X-Stainless-OS", "MacOS")
+	req.Header.Set("X-Stainless-Arch", "arm64")
+	req.Header.Set("X-Stainless-Runtime", "node")
+	req.Header.Set("X-Stainless-Runtime-Version", "v20.11.0")
+
+	fp := CaptureFingerprint(req)
+
+	require.NotNil(t, fp)
+	require.Equal(t, "claude-cli/2.0.62 (external, cli)", fp.UserAgent)
+	require.Equal(t, "js", fp.StainlessLang)
+	require.Equal(t, "0.52.0", fp.StainlessPackageVersion)
+	require.Equal(t, "MacOS", fp.StainlessOS)
+	require.Equal(t, "arm64", fp.StainlessArch)
+	require.Equal(t, "node", fp.StainlessRuntime)
+	require.Equal(t, "v20.11.0", fp.StainlessRuntimeVersion)
+	require.False(t, fp.CreatedAt.IsZero())
+}
+
+func TestCaptureFingerprint_RejectsNonClaudeCodeUserAgent(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("User-Agent", "curl/8.0.0")
+
+	require.Nil(t, CaptureFingerprint(req))
+}
+
+func TestCaptureFingerprint_NilRequestReturnsNil(t *testing.T) {
+	require.Nil(t, CaptureFingerprint(nil))
+}
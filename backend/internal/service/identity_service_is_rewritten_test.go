@@ -0,0 +1,31 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUserIDRewritten_InputFormatReturnsFalse(t *testing.T) {
+	sessionTail := "22222222-2222-2222-2222-222222222222"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+	require.False(t, IsUserIDRewritten(body))
+}
+
+func TestIsUserIDRewritten_OutputFormatReturnsTrue(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "33333333-3333-3333-3333-333333333333"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+
+	rewritten, err := svc.RewriteUserID(body, 1, "account-uuid", fixedHex64)
+	require.NoError(t, err)
+	require.True(t, IsUserIDRewritten(rewritten))
+}
+
+func TestIsUserIDRewritten_MalformedBodyReturnsFalse(t *testing.T) {
+	require.False(t, IsUserIDRewritten([]byte("not json")))
+	require.False(t, IsUserIDRewritten([]byte(`{}`)))
+	require.False(t, IsUserIDRewritten([]byte(`{"metadata":{"user_id":"plain-string"}}`)))
+}
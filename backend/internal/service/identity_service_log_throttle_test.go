@@ -0,0 +1,58 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrCreateFingerprint_ThrottlesRepeatedFixLogForSameAccount(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[201] = &Fingerprint{
+		SchemaVersion: currentFingerprintSchemaVersion,
+		ClientID:      generateClientIDForAccount(201),
+		UserAgent:     "SomeOtherClient/1.0",
+	}
+
+	svc := NewIdentityService(cache)
+
+	output := captureLogOutput(t, func() {
+		for i := 0; i < 5; i++ {
+			_, err := svc.GetOrCreateFingerprint(context.Background(), 201, http.Header{})
+			require.NoError(t, err)
+		}
+	})
+
+	require.Equal(t, 1, strings.Count(output, "Fixed fingerprint User-Agent to default for account 201"))
+}
+
+func TestGetOrCreateFingerprint_FixLogNotThrottledAcrossAccounts(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[301] = &Fingerprint{
+		SchemaVersion: currentFingerprintSchemaVersion,
+		ClientID:      generateClientIDForAccount(301),
+		UserAgent:     "SomeOtherClient/1.0",
+	}
+	cache.fingerprints[302] = &Fingerprint{
+		SchemaVersion: currentFingerprintSchemaVersion,
+		ClientID:      generateClientIDForAccount(302),
+		UserAgent:     "SomeOtherClient/1.0",
+	}
+
+	svc := NewIdentityService(cache)
+
+	output := captureLogOutput(t, func() {
+		_, err := svc.GetOrCreateFingerprint(context.Background(), 301, http.Header{})
+		require.NoError(t, err)
+		_, err = svc.GetOrCreateFingerprint(context.Background(), 302, http.Header{})
+		require.NoError(t, err)
+	})
+
+	require.Contains(t, output, "for account 301")
+	require.Contains(t, output, "for account 302")
+}
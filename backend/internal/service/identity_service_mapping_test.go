@@ -0,0 +1,65 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUserIDMappingStore is an in-memory UserIDMappingStore stub for unit tests.
+type fakeUserIDMappingStore struct {
+	entries map[string]string
+}
+
+func newFakeUserIDMappingStore() *fakeUserIDMappingStore {
+	return &fakeUserIDMappingStore{entries: make(map[string]string)}
+}
+
+func (s *fakeUserIDMappingStore) RecordUserIDMapping(_ context.Context, rewrittenUserID, originalUserID string, _ time.Duration) error {
+	s.entries[rewrittenUserID] = originalUserID
+	return nil
+}
+
+func (s *fakeUserIDMappingStore) LookupOriginalUserID(_ context.Context, rewrittenUserID string) (string, error) {
+	return s.entries[rewrittenUserID], nil
+}
+
+func TestRewriteUserID_RecordsMappingWhenEnabled(t *testing.T) {
+	svc := NewIdentityService(nil)
+	store := newFakeUserIDMappingStore()
+	svc.EnableUserIDMapping(store, time.Hour)
+
+	originalUserID := "user_" + fixedHex64 + "_account__session_11111111-1111-1111-1111-111111111111"
+	body := []byte(`{"metadata":{"user_id":"` + originalUserID + `"}}`)
+
+	rewritten, err := svc.RewriteUserID(body, 42, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+
+	var reqMap map[string]any
+	require.NoError(t, json.Unmarshal(rewritten, &reqMap))
+	newUserID := reqMap["metadata"].(map[string]any)["user_id"].(string)
+	require.NotEqual(t, originalUserID, newUserID)
+
+	got, err := svc.LookupOriginalUserID(context.Background(), newUserID)
+	require.NoError(t, err)
+	require.Equal(t, originalUserID, got)
+}
+
+func TestRewriteUserID_NoMappingRecordedWhenDisabled(t *testing.T) {
+	svc := NewIdentityService(nil)
+
+	originalUserID := "user_" + fixedHex64 + "_account__session_11111111-1111-1111-1111-111111111111"
+	body := []byte(`{"metadata":{"user_id":"` + originalUserID + `"}}`)
+
+	rewritten, err := svc.RewriteUserID(body, 42, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+
+	got, err := svc.LookupOriginalUserID(context.Background(), string(rewritten))
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
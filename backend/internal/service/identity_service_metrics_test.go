@@ -0,0 +1,60 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFingerprintMetrics 记录各类事件的调用次数，供测试断言。
+type fakeFingerprintMetrics struct {
+	hits      int
+	misses    int
+	rotations int
+}
+
+func (m *fakeFingerprintMetrics) IncFingerprintHit()      { m.hits++ }
+func (m *fakeFingerprintMetrics) IncFingerprintMiss()     { m.misses++ }
+func (m *fakeFingerprintMetrics) IncFingerprintRotation() { m.rotations++ }
+
+func TestGetOrCreateFingerprint_RecordsMissOnFirstCallThenHit(t *testing.T) {
+	metrics := &fakeFingerprintMetrics{}
+	svc := NewIdentityService(newFakeIdentityCache(), WithFingerprintMetrics(metrics))
+
+	_, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+	require.NoError(t, err)
+	require.Equal(t, 0, metrics.hits)
+	require.Equal(t, 1, metrics.misses)
+
+	_, err = svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.hits)
+	require.Equal(t, 1, metrics.misses)
+}
+
+func TestRotateFingerprint_RecordsRotation(t *testing.T) {
+	metrics := &fakeFingerprintMetrics{}
+	svc := NewIdentityService(newFakeIdentityCache(), WithFingerprintMetrics(metrics))
+
+	_, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+	require.NoError(t, err)
+
+	_, err = svc.RotateFingerprint(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.rotations)
+}
+
+func TestWithFingerprintMetrics_NilSinkDoesNotPanic(t *testing.T) {
+	svc := NewIdentityService(newFakeIdentityCache(), WithFingerprintMetrics(nil))
+
+	require.NotPanics(t, func() {
+		_, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+		require.NoError(t, err)
+		_, err = svc.RotateFingerprint(context.Background(), 1)
+		require.NoError(t, err)
+	})
+}
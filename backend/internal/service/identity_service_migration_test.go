@@ -0,0 +1,77 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIdentityCache is an in-memory IdentityCache stub for unit tests.
+type fakeIdentityCache struct {
+	fingerprints map[int64]*Fingerprint
+}
+
+func newFakeIdentityCache() *fakeIdentityCache {
+	return &fakeIdentityCache{fingerprints: make(map[int64]*Fingerprint)}
+}
+
+func (c *fakeIdentityCache) GetFingerprint(_ context.Context, accountID int64) (*Fingerprint, error) {
+	fp, ok := c.fingerprints[accountID]
+	if !ok {
+		return nil, nil
+	}
+	return fp, nil
+}
+
+func (c *fakeIdentityCache) SetFingerprint(_ context.Context, accountID int64, fp *Fingerprint) error {
+	c.fingerprints[accountID] = fp
+	return nil
+}
+
+func (c *fakeIdentityCache) AllFingerprints(_ context.Context) (map[int64]*Fingerprint, error) {
+	out := make(map[int64]*Fingerprint, len(c.fingerprints))
+	for accountID, fp := range c.fingerprints {
+		out[accountID] = fp
+	}
+	return out, nil
+}
+
+func (c *fakeIdentityCache) DeleteFingerprint(_ context.Context, accountID int64) error {
+	delete(c.fingerprints, accountID)
+	return nil
+}
+
+func TestGetOrCreateFingerprint_MigratesV1ToV2WithDefaults(t *testing.T) {
+	cache := newFakeIdentityCache()
+	// Simulate a v1 fingerprint predating SchemaVersion/CreatedAt (zero values, as JSON decode would produce).
+	cache.fingerprints[7] = &Fingerprint{
+		ClientID:  "client-abc",
+		UserAgent: "claude-cli/1.0.0 (external, cli)",
+	}
+
+	svc := NewIdentityService(cache)
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 7, http.Header{})
+
+	require.NoError(t, err)
+	require.Equal(t, currentFingerprintSchemaVersion, fp.SchemaVersion)
+	require.False(t, fp.CreatedAt.IsZero())
+
+	// Migration must be persisted back to the cache.
+	stored, err := cache.GetFingerprint(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, currentFingerprintSchemaVersion, stored.SchemaVersion)
+}
+
+func TestMigrateFingerprintToCurrent_AlreadyCurrentSchemaIsNoOp(t *testing.T) {
+	fp := &Fingerprint{
+		SchemaVersion: currentFingerprintSchemaVersion,
+		ClientID:      "client-abc",
+		UserAgent:     "claude-cli/1.0.0 (external, cli)",
+	}
+
+	require.False(t, migrateFingerprintToCurrent(fp))
+}
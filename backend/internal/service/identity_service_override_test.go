@@ -0,0 +1,62 @@
+//go:build unit
+
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFingerprintOverride_ValidSecretForcesOS(t *testing.T) {
+	svc := NewIdentityService(nil, WithFingerprintOverrideSecret("top-secret"))
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+	req.Header.Set(fingerprintOverrideSecretHeader, "top-secret")
+	req.Header.Set(fingerprintOverrideOSHeader, "Windows")
+
+	patch := svc.ParseFingerprintOverride(req.Header)
+	require.NotNil(t, patch)
+	require.Equal(t, "Windows", patch.StainlessOS)
+
+	merged := MergeFingerprintOverride(&defaultFingerprint, patch)
+	svc.ApplyFingerprint(req, merged)
+	require.Equal(t, "Windows", req.Header.Get("X-Stainless-OS"))
+}
+
+func TestParseFingerprintOverride_InvalidSecretIsIgnored(t *testing.T) {
+	svc := NewIdentityService(nil, WithFingerprintOverrideSecret("top-secret"))
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+	req.Header.Set(fingerprintOverrideSecretHeader, "wrong-secret")
+	req.Header.Set(fingerprintOverrideOSHeader, "Windows")
+
+	require.Nil(t, svc.ParseFingerprintOverride(req.Header))
+
+	svc.ApplyFingerprint(req, &defaultFingerprint)
+	require.Equal(t, defaultFingerprint.StainlessOS, req.Header.Get("X-Stainless-OS"))
+}
+
+func TestParseFingerprintOverride_SecretNotConfiguredIsIgnored(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+	req.Header.Set(fingerprintOverrideSecretHeader, "top-secret")
+	req.Header.Set(fingerprintOverrideOSHeader, "Windows")
+
+	require.Nil(t, svc.ParseFingerprintOverride(req.Header))
+}
+
+func TestApplyFingerprint_StripsOverrideHeadersBeforeForwarding(t *testing.T) {
+	svc := NewIdentityService(nil, WithFingerprintOverrideSecret("top-secret"))
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+	req.Header.Set(fingerprintOverrideSecretHeader, "top-secret")
+	req.Header.Set(fingerprintOverrideOSHeader, "Windows")
+
+	svc.ApplyFingerprint(req, &defaultFingerprint)
+
+	require.Empty(t, req.Header.Get(fingerprintOverrideSecretHeader))
+	require.Empty(t, req.Header.Get(fingerprintOverrideOSHeader))
+}
+
+func TestMergeFingerprintOverride_NilPatchReturnsBaseUnchanged(t *testing.T) {
+	require.Same(t, &defaultFingerprint, MergeFingerprintOverride(&defaultFingerprint, nil))
+}
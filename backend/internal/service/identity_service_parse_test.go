@@ -0,0 +1,50 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRewrittenUserID_ValidFormat(t *testing.T) {
+	clientID, accountUUID, sessionHash, ok := ParseRewrittenUserID(
+		"user_" + fixedHex64 + "_account_account-uuid_session_11111111-1111-1111-1111-111111111111")
+
+	require.True(t, ok)
+	require.Equal(t, fixedHex64, clientID)
+	require.Equal(t, "account-uuid", accountUUID)
+	require.Equal(t, "11111111-1111-1111-1111-111111111111", sessionHash)
+}
+
+func TestParseRewrittenUserID_InvalidFormat(t *testing.T) {
+	_, _, _, ok := ParseRewrittenUserID("not-a-rewritten-user-id")
+	require.False(t, ok)
+}
+
+func TestRewriteUserIDDetailed_ReturnsComponents(t *testing.T) {
+	svc := NewIdentityService(nil)
+	originalUserID := "user_" + fixedHex64 + "_account__session_22222222-2222-2222-2222-222222222222"
+	body := []byte(`{"metadata":{"user_id":"` + originalUserID + `"}}`)
+
+	_, components, ok, err := svc.RewriteUserIDDetailed(body, 7, "account-uuid-7", fixedHex64)
+
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, fixedHex64, components.ClientID)
+	require.Equal(t, "account-uuid-7", components.AccountUUID)
+	require.NotEmpty(t, components.SessionHash)
+}
+
+func TestRewriteUserIDDetailed_NoMatchReturnsNotOk(t *testing.T) {
+	svc := NewIdentityService(nil)
+	body := []byte(`{"metadata":{"user_id":"not-a-claude-code-user-id"}}`)
+
+	rewritten, components, ok, err := svc.RewriteUserIDDetailed(body, 7, "account-uuid-7", fixedHex64)
+
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, RewrittenUserIDComponents{}, components)
+	require.Equal(t, body, rewritten)
+}
@@ -0,0 +1,52 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrCreateFingerprint_PinnedAccountIgnoresUserAgentUpgrade(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[10] = &Fingerprint{
+		SchemaVersion: currentFingerprintSchemaVersion,
+		ClientID:      generateClientIDForAccount(10),
+		UserAgent:     "claude-cli/1.0.0 (external, cli)",
+		Pinned:        true,
+	}
+
+	svc := NewIdentityService(cache)
+	headers := http.Header{}
+	headers.Set("User-Agent", "claude-cli/2.0.62 (external, cli)")
+
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 10, headers)
+
+	require.NoError(t, err)
+	require.Equal(t, "claude-cli/1.0.0 (external, cli)", fp.UserAgent, "pinned fingerprint must not adopt the newer client User-Agent")
+
+	stored, err := cache.GetFingerprint(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, "claude-cli/1.0.0 (external, cli)", stored.UserAgent)
+}
+
+func TestGetOrCreateFingerprint_UnpinnedAccountStillUpgrades(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[11] = &Fingerprint{
+		SchemaVersion: currentFingerprintSchemaVersion,
+		ClientID:      generateClientIDForAccount(11),
+		UserAgent:     "claude-cli/1.0.0 (external, cli)",
+	}
+
+	svc := NewIdentityService(cache)
+	headers := http.Header{}
+	headers.Set("User-Agent", "claude-cli/2.0.62 (external, cli)")
+
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 11, headers)
+
+	require.NoError(t, err)
+	require.Equal(t, "claude-cli/2.0.62 (external, cli)", fp.UserAgent)
+}
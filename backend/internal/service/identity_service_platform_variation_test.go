@@ -0,0 +1,72 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPerAccountPlatformVariation_StablePerAccount(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache, WithPerAccountPlatformVariation())
+
+	first, err := svc.GetOrCreateFingerprint(context.Background(), 100, http.Header{})
+	require.NoError(t, err)
+
+	// Simulate a fresh lookup for the same account with an empty cache to confirm the
+	// selection is deterministic (derived from accountID), not randomized per call.
+	cache2 := newFakeIdentityCache()
+	svc2 := NewIdentityService(cache2, WithPerAccountPlatformVariation())
+	second, err := svc2.GetOrCreateFingerprint(context.Background(), 100, http.Header{})
+	require.NoError(t, err)
+
+	require.Equal(t, first.StainlessOS, second.StainlessOS)
+	require.Equal(t, first.StainlessArch, second.StainlessArch)
+	require.Equal(t, first.StainlessRuntimeVersion, second.StainlessRuntimeVersion)
+}
+
+func TestWithPerAccountPlatformVariation_VariesAcrossAccounts(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache, WithPerAccountPlatformVariation())
+
+	seen := make(map[string]struct{})
+	for accountID := int64(1); accountID <= int64(len(platformProfiles)*3); accountID++ {
+		fp, err := svc.GetOrCreateFingerprint(context.Background(), accountID, http.Header{})
+		require.NoError(t, err)
+		seen[fp.StainlessOS+"/"+fp.StainlessArch+"/"+fp.StainlessRuntimeVersion] = struct{}{}
+	}
+
+	require.Greater(t, len(seen), 1, "platform profiles should vary across a pool of accounts")
+}
+
+func TestWithoutPerAccountPlatformVariation_UsesFixedDefaults(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+	require.NoError(t, err)
+
+	require.Equal(t, svc.defaults.StainlessOS, fp.StainlessOS)
+	require.Equal(t, svc.defaults.StainlessArch, fp.StainlessArch)
+}
+
+func TestWithPerAccountPlatformVariation_DoesNotMutateCachedFingerprint(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[7] = &Fingerprint{
+		ClientID:      "existing-client-id",
+		UserAgent:     "claude-cli/1.0.0 (external, cli)",
+		StainlessOS:   "Linux",
+		StainlessArch: "x64",
+	}
+	svc := NewIdentityService(cache, WithPerAccountPlatformVariation())
+
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 7, http.Header{})
+	require.NoError(t, err)
+
+	require.Equal(t, "Linux", fp.StainlessOS, "cached fingerprints must not be rewritten by the option")
+	require.Equal(t, "x64", fp.StainlessArch)
+}
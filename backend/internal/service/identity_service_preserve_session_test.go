@@ -0,0 +1,30 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteUserIDWithOptions_PreserveSessionKeepsOriginalUUID(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "66666666-6666-6666-6666-666666666666"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+
+	result, err := svc.RewriteUserIDWithOptions(body, 42, "account-uuid", "cached-client-id", RewriteOptions{PreserveSession: true})
+	require.NoError(t, err)
+	require.Contains(t, string(result), "user_cached-client-id_account_account-uuid_session_"+sessionTail)
+}
+
+func TestRewriteUserID_DefaultBehaviorStillHashesSession(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "77777777-7777-7777-7777-777777777777"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+
+	result, err := svc.RewriteUserID(body, 42, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+	require.NotContains(t, string(result), sessionTail)
+	require.Contains(t, string(result), ComputeSessionHash(42, sessionTail))
+}
@@ -0,0 +1,62 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteUserIDWithResult_NotJSONReason(t *testing.T) {
+	svc := NewIdentityService(nil)
+	result, err := svc.RewriteUserIDWithResult([]byte("not json"), 1, "account-uuid", "client-id", RewriteOptions{})
+	require.NoError(t, err)
+	require.False(t, result.Rewritten)
+	require.Equal(t, RewriteReasonNotJSON, result.Reason)
+}
+
+func TestRewriteUserIDWithResult_NoMetadataReason(t *testing.T) {
+	svc := NewIdentityService(nil)
+	result, err := svc.RewriteUserIDWithResult([]byte(`{}`), 1, "account-uuid", "client-id", RewriteOptions{})
+	require.NoError(t, err)
+	require.False(t, result.Rewritten)
+	require.Equal(t, RewriteReasonNoMetadata, result.Reason)
+}
+
+func TestRewriteUserIDWithResult_NoUserIDReason(t *testing.T) {
+	svc := NewIdentityService(nil)
+	result, err := svc.RewriteUserIDWithResult([]byte(`{"metadata":{}}`), 1, "account-uuid", "client-id", RewriteOptions{})
+	require.NoError(t, err)
+	require.False(t, result.Rewritten)
+	require.Equal(t, RewriteReasonNoUserID, result.Reason)
+}
+
+func TestRewriteUserIDWithResult_PatternMismatchReason(t *testing.T) {
+	svc := NewIdentityService(nil)
+	result, err := svc.RewriteUserIDWithResult([]byte(`{"metadata":{"user_id":"not-a-known-format"}}`), 1, "account-uuid", "client-id", RewriteOptions{})
+	require.NoError(t, err)
+	require.False(t, result.Rewritten)
+	require.Equal(t, RewriteReasonPatternMismatch, result.Reason)
+}
+
+func TestRewriteUserIDWithResult_SuccessfulRewrite(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "11111111-1111-1111-1111-111111111111"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+
+	result, err := svc.RewriteUserIDWithResult(body, 1, "account-uuid", "client-id", RewriteOptions{})
+	require.NoError(t, err)
+	require.True(t, result.Rewritten)
+	require.Empty(t, result.Reason)
+	require.Contains(t, string(result.Body), "user_client-id_account_account-uuid_session_")
+}
+
+func TestRewriteUserID_StillReturnsBodyOnlyForBackwardCompatibility(t *testing.T) {
+	svc := NewIdentityService(nil)
+	body := []byte(`{"metadata":{"user_id":"not-a-known-format"}}`)
+
+	rewritten, err := svc.RewriteUserID(body, 1, "account-uuid", "client-id")
+	require.NoError(t, err)
+	require.Equal(t, body, rewritten)
+}
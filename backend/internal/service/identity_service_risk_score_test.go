@@ -0,0 +1,67 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprintRiskScore_RiskyFingerprintScoresHigh(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	risky := &Fingerprint{
+		ClientID:                "aaaaaaaa",
+		UserAgent:               svc.defaults.UserAgent,
+		StainlessOS:             svc.defaults.StainlessOS,
+		StainlessArch:           svc.defaults.StainlessArch,
+		StainlessRuntime:        svc.defaults.StainlessRuntime,
+		StainlessRuntimeVersion: svc.defaults.StainlessRuntimeVersion,
+	}
+	require.NoError(t, cache.SetFingerprint(context.Background(), 1, risky))
+	require.NoError(t, cache.SetFingerprint(context.Background(), 2, &Fingerprint{
+		ClientID: "bbbbbbbb", UserAgent: risky.UserAgent, StainlessOS: risky.StainlessOS,
+		StainlessArch: risky.StainlessArch, StainlessRuntime: risky.StainlessRuntime, StainlessRuntimeVersion: risky.StainlessRuntimeVersion,
+	}))
+	require.NoError(t, cache.SetFingerprint(context.Background(), 3, &Fingerprint{
+		ClientID: "cccccccc", UserAgent: risky.UserAgent, StainlessOS: risky.StainlessOS,
+		StainlessArch: risky.StainlessArch, StainlessRuntime: risky.StainlessRuntime, StainlessRuntimeVersion: risky.StainlessRuntimeVersion,
+	}))
+
+	score, reasons, err := svc.FingerprintRiskScore(context.Background(), 1)
+	require.NoError(t, err)
+	require.Greater(t, score, 0.5)
+	require.Contains(t, reasons, "default-only")
+	require.Contains(t, reasons, "inconsistent-fields")
+	require.Contains(t, reasons, "duplicated-shape")
+}
+
+func TestFingerprintRiskScore_WellManagedFingerprintScoresLow(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 10, nil)
+	require.NoError(t, err)
+	fp.StainlessOS = "custom-os"
+	fp.StainlessArch = "custom-arch"
+	require.NoError(t, cache.SetFingerprint(context.Background(), 10, fp))
+
+	score, reasons, err := svc.FingerprintRiskScore(context.Background(), 10)
+	require.NoError(t, err)
+	require.Less(t, score, 0.3)
+	require.Empty(t, reasons)
+	require.NotEmpty(t, fp.ClientID)
+}
+
+func TestFingerprintRiskScore_NoFingerprintReturnsZero(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	score, reasons, err := svc.FingerprintRiskScore(context.Background(), 99)
+	require.NoError(t, err)
+	require.Zero(t, score)
+	require.Empty(t, reasons)
+}
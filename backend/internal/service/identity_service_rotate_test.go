@@ -0,0 +1,90 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateFingerprint_GeneratesNewClientID(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	old, err := svc.GetOrCreateFingerprint(context.Background(), 1, nil)
+	require.NoError(t, err)
+
+	rotated, err := svc.RotateFingerprint(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotEqual(t, old.ClientID, rotated.ClientID)
+
+	stored, err := cache.GetFingerprint(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, rotated.ClientID, stored.ClientID)
+}
+
+func TestRotateFingerprint_PreservesRealClaudeCodeUserAgent(t *testing.T) {
+	cache := newFakeIdentityCache()
+	require.NoError(t, cache.SetFingerprint(context.Background(), 2, &Fingerprint{
+		ClientID:  "old-client-id",
+		UserAgent: "claude-cli/2.0.62 (external, cli)",
+	}))
+	svc := NewIdentityService(cache)
+
+	rotated, err := svc.RotateFingerprint(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, "claude-cli/2.0.62 (external, cli)", rotated.UserAgent)
+}
+
+func TestRotateFingerprint_NonClaudeCodeUserAgentFallsBackToDefault(t *testing.T) {
+	cache := newFakeIdentityCache()
+	require.NoError(t, cache.SetFingerprint(context.Background(), 3, &Fingerprint{
+		ClientID:  "old-client-id",
+		UserAgent: "SillyTavern/1.0",
+	}))
+	svc := NewIdentityService(cache)
+
+	rotated, err := svc.RotateFingerprint(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, svc.defaults.UserAgent, rotated.UserAgent)
+}
+
+func TestRotateFingerprint_NoExistingFingerprintCreatesOne(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	rotated, err := svc.RotateFingerprint(context.Background(), 4)
+	require.NoError(t, err)
+	require.NotEmpty(t, rotated.ClientID)
+	require.Equal(t, svc.defaults.UserAgent, rotated.UserAgent)
+}
+
+func TestDeleteFingerprint_RemovesCachedEntry(t *testing.T) {
+	cache := newFakeIdentityCache()
+	require.NoError(t, cache.SetFingerprint(context.Background(), 5, &Fingerprint{ClientID: "some-id"}))
+
+	require.NoError(t, cache.DeleteFingerprint(context.Background(), 5))
+
+	fp, err := cache.GetFingerprint(context.Background(), 5)
+	require.NoError(t, err)
+	require.Nil(t, fp)
+}
+
+func TestTieredIdentityCache_DeleteFingerprintRemovesFromBothLayers(t *testing.T) {
+	l1 := newFakeIdentityCache()
+	l2 := newFakeIdentityCache()
+	tiered := NewTieredIdentityCache(l1, l2, 0)
+
+	require.NoError(t, tiered.SetFingerprint(context.Background(), 6, &Fingerprint{ClientID: "some-id"}))
+	require.NoError(t, tiered.DeleteFingerprint(context.Background(), 6))
+
+	l1FP, err := l1.GetFingerprint(context.Background(), 6)
+	require.NoError(t, err)
+	require.Nil(t, l1FP)
+
+	l2FP, err := l2.GetFingerprint(context.Background(), 6)
+	require.NoError(t, err)
+	require.Nil(t, l2FP)
+}
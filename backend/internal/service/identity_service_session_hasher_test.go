@@ -0,0 +1,77 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var hex32Pattern = regexp.MustCompile(`^[a-f0-9]{32}$`)
+
+func TestWithSessionHasher_UUIDv4IsDefault(t *testing.T) {
+	svc := NewIdentityService(nil)
+
+	got := svc.ComputeSessionHash(1, "tail")
+
+	require.Equal(t, UUIDv4SessionHasher(sessionHashSeed(1, "tail", "")), got)
+}
+
+func TestWithSessionHasher_Hex32ProducesValidCompactHash(t *testing.T) {
+	svc := NewIdentityService(nil, WithSessionHasher(Hex32SessionHasher))
+
+	got := svc.ComputeSessionHash(1, "tail")
+
+	require.True(t, hex32Pattern.MatchString(got), "expected 32 hex chars, got %q", got)
+}
+
+func TestWithSessionHasher_Hex32IsDeterministic(t *testing.T) {
+	svc := NewIdentityService(nil, WithSessionHasher(Hex32SessionHasher))
+
+	require.Equal(t, svc.ComputeSessionHash(1, "tail"), svc.ComputeSessionHash(1, "tail"))
+}
+
+func TestWithSessionHasher_PrefixedHasherPrependsPrefix(t *testing.T) {
+	svc := NewIdentityService(nil, WithSessionHasher(NewPrefixedSessionHasher("sess_")))
+
+	got := svc.ComputeSessionHash(1, "tail")
+
+	require.Regexp(t, `^sess_[a-f0-9]{32}$`, got)
+}
+
+func TestWithSessionHasher_NilHasherKeepsDefault(t *testing.T) {
+	svc := NewIdentityService(nil, WithSessionHasher(nil))
+
+	require.Equal(t, UUIDv4SessionHasher(sessionHashSeed(1, "tail", "")), svc.ComputeSessionHash(1, "tail"))
+}
+
+func TestWithSessionHasher_RewriteUserIDBuildsValidUserIDForEachFormat(t *testing.T) {
+	sessionTail := "33333333-3333-3333-3333-333333333333"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+
+	hashers := map[string]SessionHasher{
+		"uuidv4":   UUIDv4SessionHasher,
+		"hex32":    Hex32SessionHasher,
+		"prefixed": NewPrefixedSessionHasher("gw-"),
+	}
+
+	for name, hasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			svc := NewIdentityService(nil, WithSessionHasher(hasher))
+
+			rewritten, err := svc.RewriteUserID(body, 1, "account-uuid", fixedHex64)
+			require.NoError(t, err)
+
+			var parsed struct {
+				Metadata struct {
+					UserID string `json:"user_id"`
+				} `json:"metadata"`
+			}
+			require.NoError(t, json.Unmarshal(rewritten, &parsed))
+			require.Regexp(t, `^user_[a-f0-9]{64}_account_account-uuid_session_.+$`, parsed.Metadata.UserID)
+		})
+	}
+}
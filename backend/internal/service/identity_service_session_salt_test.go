@@ -0,0 +1,43 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSessionSalt_DifferentSaltsYieldDifferentSessionHashes(t *testing.T) {
+	sessionTail := "11111111-1111-1111-1111-111111111111"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+
+	svcA := NewIdentityService(nil, WithSessionSalt("instance-a"))
+	svcB := NewIdentityService(nil, WithSessionSalt("instance-b"))
+
+	rewrittenA, err := svcA.RewriteUserID(body, 1, "account-uuid", fixedHex64)
+	require.NoError(t, err)
+	rewrittenB, err := svcB.RewriteUserID(body, 1, "account-uuid", fixedHex64)
+	require.NoError(t, err)
+
+	require.NotEqual(t, string(rewrittenA), string(rewrittenB))
+}
+
+func TestWithSessionSalt_UnsetPreservesUnsaltedOutput(t *testing.T) {
+	sessionTail := "22222222-2222-2222-2222-222222222222"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+
+	svc := NewIdentityService(nil)
+
+	rewritten, err := svc.RewriteUserID(body, 1, "account-uuid", fixedHex64)
+	require.NoError(t, err)
+
+	expectedHash := ComputeSessionHash(1, sessionTail)
+	require.Contains(t, string(rewritten), "_session_"+expectedHash)
+}
+
+func TestIdentityService_ComputeSessionHash_HonorsInstanceSalt(t *testing.T) {
+	svc := NewIdentityService(nil, WithSessionSalt("my-salt"))
+
+	require.NotEqual(t, ComputeSessionHash(1, "tail"), svc.ComputeSessionHash(1, "tail"))
+}
@@ -0,0 +1,95 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteUserIDStrict_MetadataString_ReturnsError(t *testing.T) {
+	svc := NewIdentityService(nil)
+	body := []byte(`{"metadata":"not-an-object"}`)
+
+	_, err := svc.RewriteUserIDStrict(body, 1, "account-uuid", "cached-client-id")
+	require.ErrorIs(t, err, ErrMetadataNotObject)
+}
+
+func TestRewriteUserIDStrict_MetadataArray_ReturnsError(t *testing.T) {
+	svc := NewIdentityService(nil)
+	body := []byte(`{"metadata":["a","b"]}`)
+
+	_, err := svc.RewriteUserIDStrict(body, 1, "account-uuid", "cached-client-id")
+	require.ErrorIs(t, err, ErrMetadataNotObject)
+}
+
+func TestRewriteUserID_MetadataString_IsNoOp(t *testing.T) {
+	svc := NewIdentityService(nil)
+	body := []byte(`{"metadata":"not-an-object"}`)
+
+	result, err := svc.RewriteUserID(body, 1, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+	require.Equal(t, body, result)
+}
+
+func TestRewriteUserIDStrict_MissingMetadata_IsNoOp(t *testing.T) {
+	svc := NewIdentityService(nil)
+	body := []byte(`{}`)
+
+	result, err := svc.RewriteUserIDStrict(body, 1, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+	require.Equal(t, body, result)
+}
+
+func TestNewIdentityService_DefaultsFromEnv_Valid(t *testing.T) {
+	t.Setenv("SUB2API_DEFAULT_UA", "claude-cli/9.9.9 (external, cli)")
+	t.Setenv("SUB2API_STAINLESS_OS", "Darwin")
+
+	svc := NewIdentityService(nil)
+
+	require.Equal(t, "claude-cli/9.9.9 (external, cli)", svc.defaults.UserAgent)
+	require.Equal(t, "Darwin", svc.defaults.StainlessOS)
+	require.Equal(t, defaultFingerprint.StainlessArch, svc.defaults.StainlessArch)
+}
+
+func TestNewIdentityService_DefaultsFromEnv_InvalidUAIgnored(t *testing.T) {
+	t.Setenv("SUB2API_DEFAULT_UA", "not-a-claude-code-ua")
+
+	svc := NewIdentityService(nil)
+
+	require.Equal(t, defaultFingerprint.UserAgent, svc.defaults.UserAgent)
+}
+
+func TestWithDefaultFingerprint_OverridesDefaults(t *testing.T) {
+	custom := Fingerprint{
+		UserAgent:               "claude-cli/3.1.0 (external, cli)",
+		StainlessOS:             "MacOS",
+		StainlessArch:           "arm64",
+		StainlessRuntime:        "node",
+		StainlessRuntimeVersion: "v20.11.0",
+	}
+
+	svc := NewIdentityService(nil, WithDefaultFingerprint(custom))
+
+	require.Equal(t, custom.UserAgent, svc.defaults.UserAgent)
+	require.Equal(t, custom.StainlessOS, svc.defaults.StainlessOS)
+	require.Equal(t, custom.StainlessArch, svc.defaults.StainlessArch)
+	require.Equal(t, custom.StainlessRuntimeVersion, svc.defaults.StainlessRuntimeVersion)
+}
+
+func TestWithDefaultFingerprint_InvalidUserAgentIgnored(t *testing.T) {
+	custom := Fingerprint{UserAgent: "not-a-claude-code-ua", StainlessOS: "MacOS"}
+
+	svc := NewIdentityService(nil, WithDefaultFingerprint(custom))
+
+	require.Equal(t, defaultFingerprint.UserAgent, svc.defaults.UserAgent)
+	require.Equal(t, defaultFingerprint.StainlessOS, svc.defaults.StainlessOS)
+}
+
+func TestWithDefaultFingerprint_NoOptionLeavesBehaviorUnchanged(t *testing.T) {
+	withOpt := NewIdentityService(nil)
+	withoutOpt := NewIdentityService(nil)
+
+	require.Equal(t, withoutOpt.defaults, withOpt.defaults)
+}
@@ -0,0 +1,54 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteUserIDWithOptions_TopLevelUserPlainStringShape(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "44444444-4444-4444-4444-444444444444"
+	body := []byte(`{"model":"gpt-4","messages":[{"role":"user","content":"hi"}],"user":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}`)
+
+	result, err := svc.RewriteUserIDWithResult(body, 1, "account-uuid", fixedHex64, RewriteOptions{RewriteTopLevelUser: true})
+	require.NoError(t, err)
+	require.True(t, result.Rewritten)
+	require.Contains(t, string(result.Body), `"user":"user_`+fixedHex64+`_account_account-uuid_session_`)
+}
+
+func TestRewriteUserIDWithOptions_TopLevelUserArrayOfContentShape(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "55555555-5555-5555-5555-555555555555"
+	body := []byte(`{"model":"gpt-4","messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}],"user":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}`)
+
+	result, err := svc.RewriteUserIDWithResult(body, 1, "account-uuid", fixedHex64, RewriteOptions{RewriteTopLevelUser: true})
+	require.NoError(t, err)
+	require.True(t, result.Rewritten)
+	require.Contains(t, string(result.Body), `"user":"user_`+fixedHex64+`_account_account-uuid_session_`)
+	require.Contains(t, string(result.Body), `"type":"text"`)
+}
+
+func TestRewriteUserIDWithOptions_TopLevelUserIgnoredByDefault(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "66666666-6666-6666-6666-666666666660"
+	body := []byte(`{"user":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}`)
+
+	result, err := svc.RewriteUserIDWithResult(body, 1, "account-uuid", fixedHex64, RewriteOptions{})
+	require.NoError(t, err)
+	require.False(t, result.Rewritten)
+	require.Equal(t, RewriteReasonNoMetadata, result.Reason)
+}
+
+func TestRewriteUserIDWithOptions_MetadataUserIDTakesPrecedenceOverTopLevelUser(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "77777777-7777-7777-7777-777777777770"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"},"user":"plain-string-not-touched"}`)
+
+	result, err := svc.RewriteUserIDWithResult(body, 1, "account-uuid", fixedHex64, RewriteOptions{RewriteTopLevelUser: true})
+	require.NoError(t, err)
+	require.True(t, result.Rewritten)
+	require.Contains(t, string(result.Body), `"user":"plain-string-not-touched"`)
+}
@@ -0,0 +1,75 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+)
+
+// recordedSpan captures the attributes set on a single fakeSpan for assertions.
+type recordedSpan struct {
+	name       string
+	attributes map[string]any
+	ended      bool
+}
+
+type fakeSpan struct {
+	record *recordedSpan
+}
+
+func (s *fakeSpan) SetAttribute(key string, value any) {
+	s.record.attributes[key] = value
+}
+
+func (s *fakeSpan) End() {
+	s.record.ended = true
+}
+
+// fakeTracer is a claude.Tracer test double that records every span it starts.
+type fakeTracer struct {
+	spans []*recordedSpan
+}
+
+func (t *fakeTracer) StartSpan(ctx context.Context, name string) (context.Context, claude.Span) {
+	record := &recordedSpan{name: name, attributes: make(map[string]any)}
+	t.spans = append(t.spans, record)
+	return ctx, &fakeSpan{record: record}
+}
+
+func TestWithTracer_RecordsSpanForFingerprintLookup(t *testing.T) {
+	tracer := &fakeTracer{}
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache, WithTracer(tracer))
+
+	_, err := svc.GetOrCreateFingerprint(context.Background(), 42, http.Header{})
+	require.NoError(t, err)
+
+	require.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	require.Equal(t, "identity.GetOrCreateFingerprint", span.name)
+	require.True(t, span.ended)
+	require.Equal(t, int64(42), span.attributes["account_id"])
+	require.Contains(t, span.attributes, "genuine")
+}
+
+func TestWithTracer_NilTracerIsIgnored(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache, WithTracer(nil))
+
+	_, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+	require.NoError(t, err)
+}
+
+func TestWithoutTracer_DefaultsToNoop(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	_, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+	require.NoError(t, err)
+}
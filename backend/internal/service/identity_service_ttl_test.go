@@ -0,0 +1,70 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrCreateFingerprint_RotatesClientIDOnceTTLExpires(t *testing.T) {
+	cache := newFakeIdentityCache()
+	cache.fingerprints[1] = &Fingerprint{
+		SchemaVersion:           currentFingerprintSchemaVersion,
+		ClientID:                generateClientIDForAccount(1),
+		UserAgent:               "claude-cli/2.0.62 (external, cli)",
+		StainlessOS:             "Linux",
+		StainlessArch:           "x64",
+		StainlessRuntimeVersion: "v22.14.0",
+		CreatedAt:               time.Now().Add(-2 * time.Hour),
+	}
+
+	svc := NewIdentityService(cache, WithFingerprintTTL(time.Hour))
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+
+	require.NoError(t, err)
+	require.NotEqual(t, cache.fingerprints[1].ClientID, "")
+	require.Equal(t, "claude-cli/2.0.62 (external, cli)", fp.UserAgent)
+	require.Equal(t, "Linux", fp.StainlessOS)
+	require.Equal(t, "x64", fp.StainlessArch)
+	require.WithinDuration(t, time.Now(), fp.CreatedAt, time.Minute)
+}
+
+func TestGetOrCreateFingerprint_NeverExpiresByDefault(t *testing.T) {
+	cache := newFakeIdentityCache()
+	original := generateClientIDForAccount(1)
+	cache.fingerprints[1] = &Fingerprint{
+		SchemaVersion: currentFingerprintSchemaVersion,
+		ClientID:      original,
+		UserAgent:     "claude-cli/2.0.62 (external, cli)",
+		CreatedAt:     time.Now().Add(-24 * 365 * time.Hour),
+	}
+
+	svc := NewIdentityService(cache)
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+
+	require.NoError(t, err)
+	require.Equal(t, original, fp.ClientID)
+}
+
+func TestGetOrCreateFingerprint_PinnedFingerprintIgnoresTTL(t *testing.T) {
+	cache := newFakeIdentityCache()
+	original := generateClientIDForAccount(1)
+	cache.fingerprints[1] = &Fingerprint{
+		SchemaVersion: currentFingerprintSchemaVersion,
+		ClientID:      original,
+		UserAgent:     "claude-cli/2.0.62 (external, cli)",
+		CreatedAt:     time.Now().Add(-2 * time.Hour),
+		Pinned:        true,
+	}
+
+	svc := NewIdentityService(cache, WithFingerprintTTL(time.Hour))
+	fp, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+
+	require.NoError(t, err)
+	require.Equal(t, original, fp.ClientID)
+}
@@ -0,0 +1,43 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomUserAgentFromPool_FavorsNewerVersionsStatistically(t *testing.T) {
+	svc := NewIdentityService(nil)
+
+	const iterations = 5000
+	counts := make(map[string]int)
+	for i := 0; i < iterations; i++ {
+		counts[svc.RandomUserAgentFromPool()]++
+	}
+
+	oldest := defaultUAVersionWeights[0].UserAgent
+	newest := defaultUAVersionWeights[len(defaultUAVersionWeights)-1].UserAgent
+	require.Greater(t, counts[newest], counts[oldest])
+}
+
+func TestWithUAVersionPool_OverridesDefaultWeights(t *testing.T) {
+	svc := NewIdentityService(nil, WithUAVersionPool([]UAVersionWeight{
+		{UserAgent: "claude-cli/9.9.9 (external, cli)", Weight: 1},
+	}))
+
+	require.Equal(t, "claude-cli/9.9.9 (external, cli)", svc.RandomUserAgentFromPool())
+}
+
+func TestWithUAVersionPool_EmptyOrNonPositiveWeightsAreIgnored(t *testing.T) {
+	base := NewIdentityService(nil)
+	baseline := base.RandomUserAgentFromPool()
+	_ = baseline
+
+	svc := NewIdentityService(nil, WithUAVersionPool(nil))
+	require.Equal(t, defaultUAVersionWeights, svc.uaVersionPool)
+
+	svc2 := NewIdentityService(nil, WithUAVersionPool([]UAVersionWeight{{UserAgent: "x", Weight: 0}}))
+	require.Equal(t, defaultUAVersionWeights, svc2.uaVersionPool)
+}
@@ -0,0 +1,66 @@
+//go:build unit
+
+package service
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteUserID_NewClientFormatWithAccountUUID_IsRewritten(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "33333333-3333-3333-3333-333333333333"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account_some-client-account-uuid_session_` + sessionTail + `"}}`)
+
+	result, err := svc.RewriteUserID(body, 99, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+
+	expected := ComputeSessionHash(99, sessionTail)
+	require.Contains(t, string(result), expected)
+	require.Contains(t, string(result), "user_cached-client-id_account_account-uuid_session_")
+}
+
+func TestRewriteUserID_AlreadyRewrittenFormat_RederivesSessionHash(t *testing.T) {
+	svc := NewIdentityService(nil)
+	// 已经是本服务输出格式的 user_id：sessionHash 段本身被当作 session_tail 重新派生
+	priorHash := "44444444-4444-4444-4444-444444444444"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account_old-account-uuid_session_` + priorHash + `"}}`)
+
+	result, err := svc.RewriteUserID(body, 5, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+
+	expected := ComputeSessionHash(5, priorHash)
+	require.Contains(t, string(result), expected)
+}
+
+func TestWithUserIDPatterns_OverridesDefaultList(t *testing.T) {
+	customPattern := regexp.MustCompile(`^custom_(?P<session_tail>[a-f0-9-]{36})$`)
+	svc := NewIdentityService(nil, WithUserIDPatterns([]*regexp.Regexp{customPattern}))
+
+	sessionTail := "55555555-5555-5555-5555-555555555555"
+	body := []byte(`{"metadata":{"user_id":"custom_` + sessionTail + `"}}`)
+
+	result, err := svc.RewriteUserID(body, 7, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+	require.Contains(t, string(result), ComputeSessionHash(7, sessionTail))
+
+	// 默认格式不再被识别，因为自定义列表整体替换了默认模式
+	legacyBody := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+	legacyResult, err := svc.RewriteUserID(legacyBody, 7, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+	require.Equal(t, legacyBody, legacyResult)
+}
+
+func TestWithUserIDPatterns_PatternWithoutSessionTailGroupIsIgnored(t *testing.T) {
+	invalidPattern := regexp.MustCompile(`^custom_([a-f0-9-]{36})$`)
+	svc := NewIdentityService(nil, WithUserIDPatterns([]*regexp.Regexp{invalidPattern}))
+
+	require.Equal(t, defaultUserIDPatterns, svc.userIDPatterns)
+}
+
+func TestWithUserIDPatterns_EmptyListLeavesDefaultsUnchanged(t *testing.T) {
+	svc := NewIdentityService(nil, WithUserIDPatterns(nil))
+	require.Equal(t, defaultUserIDPatterns, svc.userIDPatterns)
+}
@@ -0,0 +1,41 @@
+//go:build unit
+
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAppliedHeaders_CompleteFingerprintReportsNoneMissing(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+
+	svc.ApplyFingerprint(req, &defaultFingerprint)
+
+	require.Empty(t, VerifyAppliedHeaders(req))
+}
+
+func TestVerifyAppliedHeaders_PartialFingerprintReportsMissingStainlessHeaders(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+
+	fp := defaultFingerprint
+	fp.StainlessLang = ""
+	fp.StainlessArch = ""
+	svc.ApplyFingerprint(req, &fp)
+
+	missing := VerifyAppliedHeaders(req)
+	require.ElementsMatch(t, []string{"X-Stainless-Lang", "X-Stainless-Arch"}, missing)
+}
+
+func TestVerifyAppliedHeaders_NilFingerprintReportsAllMissing(t *testing.T) {
+	svc := NewIdentityService(nil)
+	req := httptest.NewRequest("POST", "https://example.com/v1/messages", nil)
+
+	svc.ApplyFingerprint(req, nil)
+
+	require.ElementsMatch(t, requiredAppliedHeaders, VerifyAppliedHeaders(req))
+}
@@ -0,0 +1,96 @@
+package service
+
+import "testing"
+
+func TestCompareClaudeCodeVersion_NewerReturnsPositive(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62 (external, cli)", "claude-cli/1.9.0 (external, cli)")
+	if !ok || cmp != 1 {
+		t.Fatalf("expected (1, true), got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_OlderReturnsNegative(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/1.0.0 (external, cli)", "claude-cli/2.0.62 (external, cli)")
+	if !ok || cmp != -1 {
+		t.Fatalf("expected (-1, true), got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_EqualReturnsZero(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62 (external, cli)", "claude-cli/2.0.62 (external, cli)")
+	if !ok || cmp != 0 {
+		t.Fatalf("expected (0, true), got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_MalformedUAIsNotOK(t *testing.T) {
+	_, ok := CompareClaudeCodeVersion("SillyTavern/1.0", "claude-cli/2.0.62 (external, cli)")
+	if ok {
+		t.Fatal("expected ok=false when one UA doesn't match claude-cli/x.y.z")
+	}
+}
+
+func TestCompareClaudeCodeVersion_BothMalformedIsNotOK(t *testing.T) {
+	_, ok := CompareClaudeCodeVersion("curl/8.0", "SillyTavern/1.0")
+	if ok {
+		t.Fatal("expected ok=false when neither UA matches claude-cli/x.y.z")
+	}
+}
+
+func TestCompareClaudeCodeVersion_ReleaseIsNewerThanPrerelease(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62 (external, cli)", "claude-cli/2.0.62-beta.1 (external, cli)")
+	if !ok || cmp != 1 {
+		t.Fatalf("expected (1, true), got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_EqualVersionsIgnoringPrereleaseTag(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62-beta.1 (external, cli)", "claude-cli/2.0.62-beta.1 (external, cli)")
+	if !ok || cmp != 0 {
+		t.Fatalf("expected (0, true), got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_FourComponentVersion(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62.4 (external, cli)", "claude-cli/2.0.62.1 (external, cli)")
+	if !ok || cmp != 1 {
+		t.Fatalf("expected (1, true), got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_FourComponentVsThreeComponentTreatsMissingAsZero(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62 (external, cli)", "claude-cli/2.0.62.1 (external, cli)")
+	if !ok || cmp != -1 {
+		t.Fatalf("expected (-1, true), got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_PrereleaseOrdinalComparison(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62-beta.2 (external, cli)", "claude-cli/2.0.62-beta.1 (external, cli)")
+	if !ok || cmp != 1 {
+		t.Fatalf("expected (1, true), got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_BuildMetadataIsIgnored(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62+build.5 (external, cli)", "claude-cli/2.0.62+build.9 (external, cli)")
+	if !ok || cmp != 0 {
+		t.Fatalf("expected build metadata to be ignored in comparison, got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestCompareClaudeCodeVersion_BuildMetadataWithPrerelease(t *testing.T) {
+	cmp, ok := CompareClaudeCodeVersion("claude-cli/2.0.62-beta.1+build.5 (external, cli)", "claude-cli/2.0.62 (external, cli)")
+	if !ok || cmp != -1 {
+		t.Fatalf("expected prerelease with build metadata to still be older than release, got (%d, %v)", cmp, ok)
+	}
+}
+
+func TestIsNewerClaudeCodeVersion_DelegatesToCompare(t *testing.T) {
+	if !isNewerClaudeCodeVersion("claude-cli/2.0.62 (external, cli)", "claude-cli/2.0.61 (external, cli)") {
+		t.Fatal("expected patch bump to be detected as newer")
+	}
+	if isNewerClaudeCodeVersion("claude-cli/2.0.61 (external, cli)", "claude-cli/2.0.62 (external, cli)") {
+		t.Fatal("older patch must not be considered newer")
+	}
+}
@@ -0,0 +1,91 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmFingerprints_CreatesFingerprintsForNewAccounts(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	err := svc.WarmFingerprints(context.Background(), []int64{1, 2, 3})
+	require.NoError(t, err)
+
+	for _, accountID := range []int64{1, 2, 3} {
+		fp, getErr := cache.GetFingerprint(context.Background(), accountID)
+		require.NoError(t, getErr)
+		require.NotNil(t, fp)
+		require.NotEmpty(t, fp.ClientID)
+	}
+}
+
+func TestWarmFingerprints_SkipsAccountsWithExistingFingerprint(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	existing, err := svc.GetOrCreateFingerprint(context.Background(), 1, http.Header{})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.WarmFingerprints(context.Background(), []int64{1}))
+
+	after, err := cache.GetFingerprint(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, existing.ClientID, after.ClientID)
+}
+
+// failingIdentityCache wraps fakeIdentityCache and fails SetFingerprint for a specific accountID,
+// letting tests exercise WarmFingerprints' partial-failure combined-error path.
+type failingIdentityCache struct {
+	*fakeIdentityCache
+	failAccountID int64
+}
+
+func (c *failingIdentityCache) SetFingerprint(ctx context.Context, accountID int64, fp *Fingerprint) error {
+	if accountID == c.failAccountID {
+		return errors.New("cache unavailable")
+	}
+	return c.fakeIdentityCache.SetFingerprint(ctx, accountID, fp)
+}
+
+func TestWarmFingerprints_ReturnsCombinedErrorOnPartialFailure(t *testing.T) {
+	cache := &failingIdentityCache{fakeIdentityCache: newFakeIdentityCache(), failAccountID: 2}
+	svc := NewIdentityService(cache)
+
+	err := svc.WarmFingerprints(context.Background(), []int64{1, 2, 3})
+	require.Error(t, err)
+
+	fp1, getErr := cache.GetFingerprint(context.Background(), 1)
+	require.NoError(t, getErr)
+	require.NotNil(t, fp1)
+
+	fp3, getErr := cache.GetFingerprint(context.Background(), 3)
+	require.NoError(t, getErr)
+	require.NotNil(t, fp3)
+}
+
+func TestWarmFingerprints_SafeForConcurrentCalls(t *testing.T) {
+	cache := newFakeIdentityCache()
+	svc := NewIdentityService(cache)
+
+	var wg sync.WaitGroup
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer wg.Done()
+			require.NoError(t, svc.WarmFingerprints(context.Background(), []int64{1, 2, 3}))
+		}()
+	}
+	wg.Wait()
+
+	fp, err := cache.GetFingerprint(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, fp)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
@@ -11,8 +12,17 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ratelog"
 )
 
+// authErrorLogWindow 是同一账号"禁用于认证错误"日志的去重窗口，
+// 避免账号在冷却生效前被反复命中导致的日志刷屏
+const authErrorLogWindow = time.Minute
+
+// defaultSweepIdleTTL 是 Sweep 默认使用的空闲判定阈值：账号超过该时长未产生新的
+// Gemini 用量预检缓存或认证错误日志，视为已churn，对应状态会被清理。
+const defaultSweepIdleTTL = time.Hour
+
 // RateLimitService 处理限流和过载状态管理
 type RateLimitService struct {
 	accountRepo        AccountRepository
@@ -22,6 +32,10 @@ type RateLimitService struct {
 	tempUnschedCache   TempUnschedCache
 	usageCacheMu       sync.RWMutex
 	usageCache         map[int64]*geminiUsageCacheEntry
+	authErrorLog       *ratelog.Logger
+
+	// sweepIdleTTL 见 SetSweepIdleTTL
+	sweepIdleTTL time.Duration
 }
 
 type geminiUsageCacheEntry struct {
@@ -41,7 +55,32 @@ func NewRateLimitService(accountRepo AccountRepository, usageRepo UsageLogReposi
 		geminiQuotaService: geminiQuotaService,
 		tempUnschedCache:   tempUnschedCache,
 		usageCache:         make(map[int64]*geminiUsageCacheEntry),
+		authErrorLog:       ratelog.New(authErrorLogWindow),
+		sweepIdleTTL:       defaultSweepIdleTTL,
+	}
+}
+
+// SetSweepIdleTTL 配置 Sweep 判定账号"已空闲"的阈值。非正值被忽略，保留当前设置。
+func (s *RateLimitService) SetSweepIdleTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	s.sweepIdleTTL = ttl
+}
+
+// Sweep 清理超过 sweepIdleTTL 未活跃账号的内存态（Gemini 用量预检缓存、认证错误日志
+// 去重窗口），避免账号池不断变化时这些 map 无限增长。可安全在定时器上周期性调用，
+// 也可与正常的读写请求并发执行。
+func (s *RateLimitService) Sweep(now time.Time) {
+	s.usageCacheMu.Lock()
+	for accountID, entry := range s.usageCache {
+		if now.Sub(entry.cachedAt) >= s.sweepIdleTTL {
+			delete(s.usageCache, accountID)
+		}
 	}
+	s.usageCacheMu.Unlock()
+
+	s.authErrorLog.Sweep(now, s.sweepIdleTTL)
 }
 
 // HandleUpstreamError 处理上游错误响应，标记账号状态
@@ -74,9 +113,16 @@ func (s *RateLimitService) HandleUpstreamError(ctx context.Context, account *Acc
 		s.handleAuthError(ctx, account, "Payment required (402): insufficient balance or billing issue")
 		shouldDisable = true
 	case 403:
-		// 禁止访问：停止调度，记录错误
-		s.handleAuthError(ctx, account, "Access forbidden (403): account may be suspended or lack permissions")
-		shouldDisable = true
+		// permission_error 表示账号缺少某项资源的访问权限（如未加入某个组织/工作区），
+		// 账号本身仍然有效，不应停止调度；其余 403（如 authentication_error 或无法识别）
+		// 按原有逻辑视为致命错误处理。
+		if classify403(responseBody) == error403Permission {
+			log.Printf("Account %d received 403 permission_error, not disabling account", account.ID)
+			shouldDisable = false
+		} else {
+			s.handleAuthError(ctx, account, "Access forbidden (403): account may be suspended or lack permissions")
+			shouldDisable = true
+		}
 	case 429:
 		s.handle429(ctx, account, headers)
 		shouldDisable = false
@@ -261,7 +307,7 @@ func (s *RateLimitService) handleAuthError(ctx context.Context, account *Account
 		log.Printf("SetError failed for account %d: %v", account.ID, err)
 		return
 	}
-	log.Printf("Account %d disabled due to auth error: %s", account.ID, errorMsg)
+	s.authErrorLog.Printf(fmt.Sprintf("auth-disabled:%d", account.ID), "Account %d disabled due to auth error: %s", account.ID, errorMsg)
 }
 
 // fatal400ErrorPatterns 定义需要禁用账号的 400 错误消息模式
@@ -280,9 +326,55 @@ var fatal400ErrorPatterns = []struct {
 	{"workspace is disabled", "Workspace disabled (400): this workspace is disabled"},
 }
 
+// error403Permission 与 error403Authentication 是 Anthropic 403 响应体中 error.type 的
+// 已知取值：permission_error 表示账号缺少某资源的访问权限（组织/工作区级别），账号本身仍
+// 可用；authentication_error 表示凭证本身失效。
+const (
+	error403Permission     = "permission_error"
+	error403Authentication = "authentication_error"
+)
+
+// anthropic403ErrorBody 是 Anthropic 错误响应体中与分类相关的最小子集
+type anthropic403ErrorBody struct {
+	Error struct {
+		Type string `json:"type"`
+	} `json:"error"`
+}
+
+// classify403 解析 403 响应体的 error.type，用于区分 permission_error（不代表账号失效）
+// 与 authentication_error 等其他类型。无法解析或类型未知时返回空字符串。
+func classify403(responseBody []byte) string {
+	if len(responseBody) == 0 {
+		return ""
+	}
+
+	body := responseBody
+	if len(body) > 4096 {
+		body = body[:4096]
+	}
+
+	var parsed anthropic403ErrorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return ""
+	}
+
+	switch parsed.Error.Type {
+	case error403Permission, error403Authentication:
+		return parsed.Error.Type
+	default:
+		return ""
+	}
+}
+
 // checkFatal400Error 检查 400 错误响应是否为致命错误（需要禁用账号）
 // 返回错误消息（如果是致命错误）或空字符串（如果不是）
 func (s *RateLimitService) checkFatal400Error(responseBody []byte) string {
+	return fatal400ErrorMessage(responseBody)
+}
+
+// fatal400ErrorMessage 是 checkFatal400Error 的无状态实现：不依赖 RateLimitService 的任何
+// 字段，供不持有 RateLimitService 实例的调用方（如 ShouldRetryUpstream）直接复用。
+func fatal400ErrorMessage(responseBody []byte) string {
 	if len(responseBody) == 0 {
 		return ""
 	}
@@ -364,6 +456,56 @@ func (s *RateLimitService) handle529(ctx context.Context, account *Account) {
 	log.Printf("Account %d overloaded until %v", account.ID, until)
 }
 
+// defaultRateLimitRetryDelay 是 429 响应缺失或无法解析重置时间戳时使用的兜底等待时间，
+// 与 handle429 在同样情况下用于标记账号限流状态的时长保持一致。
+const defaultRateLimitRetryDelay = 5 * time.Minute
+
+// shortOverloadRetryDelay 是 529 过载错误建议的重试等待时间。调用方通常会换一个账号立即
+// 重试，而不是在同一账号上等待 handle529 使用的完整过载冷却时间，因此这里用一个更短的固定值。
+const shortOverloadRetryDelay = 2 * time.Second
+
+// rateLimitResetDelay 解析 anthropic-ratelimit-unified-reset 响应头，返回距重置时间还需
+// 等待多久；缺失或无法解析时返回 defaultRateLimitRetryDelay。
+func rateLimitResetDelay(headers http.Header) time.Duration {
+	resetTimestamp := headers.Get("anthropic-ratelimit-unified-reset")
+	if resetTimestamp == "" {
+		return defaultRateLimitRetryDelay
+	}
+
+	ts, err := strconv.ParseInt(resetTimestamp, 10, 64)
+	if err != nil {
+		return defaultRateLimitRetryDelay
+	}
+
+	delay := time.Until(time.Unix(ts, 0))
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// ShouldRetryUpstream 集中上游瞬时错误的重试策略，供代理转发路径统一判断某次上游失败是否
+// 值得换一个账号重试，以及重试前应该等待多久：
+//   - 400 且响应体匹配 fatal400ErrorPatterns（账号/组织级别被禁用）：不重试，换账号也没用；
+//   - 429：重试，等待时间取自限流重置时间头（缺失时使用 defaultRateLimitRetryDelay）；
+//   - 529：重试，使用较短的固定等待时间，上游过载通常是瞬时的；
+//   - 其余状态码（包括 2xx/3xx 与未特殊处理的 4xx/5xx）：不重试，交由调用方按已有逻辑处理。
+func ShouldRetryUpstream(status int, headers http.Header, body []byte) (retry bool, after time.Duration) {
+	switch status {
+	case 400:
+		if fatal400ErrorMessage(body) != "" {
+			return false, 0
+		}
+		return false, 0
+	case 429:
+		return true, rateLimitResetDelay(headers)
+	case 529:
+		return true, shortOverloadRetryDelay
+	default:
+		return false, 0
+	}
+}
+
 // UpdateSessionWindow 从成功响应更新5h窗口状态
 func (s *RateLimitService) UpdateSessionWindow(ctx context.Context, account *Account, headers http.Header) {
 	status := headers.Get("anthropic-ratelimit-unified-5h-status")
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestShouldRetryUpstream_Fatal400DoesNotRetry(t *testing.T) {
+	body := []byte(`{"error":{"message":"This organization has been disabled"}}`)
+
+	retry, after := ShouldRetryUpstream(400, http.Header{}, body)
+
+	if retry {
+		t.Errorf("ShouldRetryUpstream() retry = true, want false for fatal 400 error")
+	}
+	if after != 0 {
+		t.Errorf("ShouldRetryUpstream() after = %v, want 0", after)
+	}
+}
+
+func TestShouldRetryUpstream_429RetriesAfterResetTime(t *testing.T) {
+	resetAt := time.Now().Add(90 * time.Second)
+	headers := http.Header{}
+	headers.Set("anthropic-ratelimit-unified-reset", strconv.FormatInt(resetAt.Unix(), 10))
+
+	retry, after := ShouldRetryUpstream(429, headers, nil)
+
+	if !retry {
+		t.Fatal("ShouldRetryUpstream() retry = false, want true for 429")
+	}
+	if after <= 0 || after > 90*time.Second {
+		t.Errorf("ShouldRetryUpstream() after = %v, want roughly up to 90s", after)
+	}
+}
+
+func TestShouldRetryUpstream_429FallsBackWithoutResetHeader(t *testing.T) {
+	retry, after := ShouldRetryUpstream(429, http.Header{}, nil)
+
+	if !retry {
+		t.Fatal("ShouldRetryUpstream() retry = false, want true for 429")
+	}
+	if after != defaultRateLimitRetryDelay {
+		t.Errorf("ShouldRetryUpstream() after = %v, want default %v", after, defaultRateLimitRetryDelay)
+	}
+}
+
+func TestShouldRetryUpstream_529RetriesWithShortDelay(t *testing.T) {
+	retry, after := ShouldRetryUpstream(529, http.Header{}, nil)
+
+	if !retry {
+		t.Fatal("ShouldRetryUpstream() retry = false, want true for 529")
+	}
+	if after != shortOverloadRetryDelay {
+		t.Errorf("ShouldRetryUpstream() after = %v, want %v", after, shortOverloadRetryDelay)
+	}
+}
+
+func TestShouldRetryUpstream_200DoesNotRetry(t *testing.T) {
+	retry, after := ShouldRetryUpstream(200, http.Header{}, nil)
+
+	if retry {
+		t.Errorf("ShouldRetryUpstream() retry = true, want false for 200")
+	}
+	if after != 0 {
+		t.Errorf("ShouldRetryUpstream() after = %v, want 0", after)
+	}
+}
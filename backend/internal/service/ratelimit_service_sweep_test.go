@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ratelog"
+)
+
+func TestRateLimitService_SweepEvictsIdleUsageCacheEntries(t *testing.T) {
+	now := time.Now()
+	s := &RateLimitService{
+		usageCache: map[int64]*geminiUsageCacheEntry{
+			1: {cachedAt: now.Add(-2 * time.Hour)},
+			2: {cachedAt: now},
+		},
+		authErrorLog: ratelog.New(time.Minute),
+		sweepIdleTTL: time.Hour,
+	}
+
+	s.Sweep(now)
+
+	if _, ok := s.usageCache[1]; ok {
+		t.Fatal("idle account state should have been evicted")
+	}
+	if _, ok := s.usageCache[2]; !ok {
+		t.Fatal("active account state should persist")
+	}
+}
+
+func TestRateLimitService_SweepIsSafeUnderConcurrentAccess(t *testing.T) {
+	now := time.Now()
+	s := &RateLimitService{
+		usageCache:   make(map[int64]*geminiUsageCacheEntry),
+		authErrorLog: ratelog.New(time.Minute),
+		sweepIdleTTL: time.Hour,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			s.usageCacheMu.Lock()
+			s.usageCache[int64(i)] = &geminiUsageCacheEntry{cachedAt: now}
+			s.usageCacheMu.Unlock()
+		}
+		close(done)
+	}()
+
+	for i := 0; i < 100; i++ {
+		s.Sweep(now)
+	}
+	<-done
+}
+
+func TestRateLimitService_SetSweepIdleTTL_IgnoresNonPositiveValue(t *testing.T) {
+	s := &RateLimitService{sweepIdleTTL: defaultSweepIdleTTL}
+
+	s.SetSweepIdleTTL(0)
+
+	if s.sweepIdleTTL != defaultSweepIdleTTL {
+		t.Fatalf("expected sweepIdleTTL to remain %v, got %v", defaultSweepIdleTTL, s.sweepIdleTTL)
+	}
+}
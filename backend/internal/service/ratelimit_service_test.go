@@ -88,6 +88,48 @@ func TestCheckFatal400Error(t *testing.T) {
 	}
 }
 
+func TestClassify403(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseBody []byte
+		want         string
+	}{
+		{
+			name:         "permission error",
+			responseBody: []byte(`{"type":"error","error":{"type":"permission_error","message":"Your account does not have access to this resource."}}`),
+			want:         error403Permission,
+		},
+		{
+			name:         "authentication error",
+			responseBody: []byte(`{"type":"error","error":{"type":"authentication_error","message":"Invalid API key."}}`),
+			want:         error403Authentication,
+		},
+		{
+			name:         "unrecognized error type",
+			responseBody: []byte(`{"type":"error","error":{"type":"some_other_error","message":"Unknown."}}`),
+			want:         "",
+		},
+		{
+			name:         "empty body",
+			responseBody: []byte(``),
+			want:         "",
+		},
+		{
+			name:         "malformed json",
+			responseBody: []byte(`not json`),
+			want:         "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classify403(tt.responseBody); got != tt.want {
+				t.Errorf("classify403() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && len(substr) > 0 && findSubstring(s, substr)))
@@ -0,0 +1,21 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeSessionHash_MatchesRewriteUserID(t *testing.T) {
+	svc := NewIdentityService(nil)
+	sessionTail := "22222222-2222-2222-2222-222222222222"
+	body := []byte(`{"metadata":{"user_id":"user_` + fixedHex64 + `_account__session_` + sessionTail + `"}}`)
+
+	result, err := svc.RewriteUserID(body, 42, "account-uuid", "cached-client-id")
+	require.NoError(t, err)
+
+	expected := ComputeSessionHash(42, sessionTail)
+	require.Contains(t, string(result), expected)
+}
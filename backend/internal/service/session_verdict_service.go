@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+)
+
+// SessionVerdictCache 按会话哈希持久化检测判定结果（claude.Grade），供 SessionVerdictService
+// 在同一会话的后续请求中直接复用首次判定，避免重复计算相似度。
+type SessionVerdictCache interface {
+	GetSessionVerdict(ctx context.Context, sessionHash string) (grade claude.Grade, ok bool, err error)
+	SetSessionVerdict(ctx context.Context, sessionHash string, grade claude.Grade, ttl time.Duration) error
+}
+
+// SessionVerdictTTL 是会话判定结果的默认缓存时长，与粘性会话窗口对齐
+const SessionVerdictTTL = time.Hour
+
+// SessionVerdictService 为按会话分级限速提供判定结果的读写：同一会话的首个请求判定后，
+// 后续请求复用该判定而无需对每个请求重新分类，节省相似度计算开销。
+type SessionVerdictService struct {
+	cache SessionVerdictCache
+}
+
+// NewSessionVerdictService 创建新的 SessionVerdictService
+func NewSessionVerdictService(cache SessionVerdictCache) *SessionVerdictService {
+	return &SessionVerdictService{cache: cache}
+}
+
+// GetSessionVerdict 返回给定会话已缓存的判定结果；未命中或 sessionHash 为空时 ok 为 false
+func (s *SessionVerdictService) GetSessionVerdict(ctx context.Context, sessionHash string) (claude.Grade, bool, error) {
+	if sessionHash == "" || s.cache == nil {
+		return claude.GradeNotGenuine, false, nil
+	}
+	return s.cache.GetSessionVerdict(ctx, sessionHash)
+}
+
+// SetSessionVerdict 以 SessionVerdictTTL 缓存给定会话的判定结果；sessionHash 为空时不做任何事
+func (s *SessionVerdictService) SetSessionVerdict(ctx context.Context, sessionHash string, grade claude.Grade) error {
+	if sessionHash == "" || s.cache == nil {
+		return nil
+	}
+	return s.cache.SetSessionVerdict(ctx, sessionHash, grade, SessionVerdictTTL)
+}
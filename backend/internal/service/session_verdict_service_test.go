@@ -0,0 +1,70 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessionVerdictCache is an in-memory SessionVerdictCache stub for unit tests.
+type fakeSessionVerdictCache struct {
+	verdicts map[string]claude.Grade
+}
+
+func newFakeSessionVerdictCache() *fakeSessionVerdictCache {
+	return &fakeSessionVerdictCache{verdicts: make(map[string]claude.Grade)}
+}
+
+func (c *fakeSessionVerdictCache) GetSessionVerdict(_ context.Context, sessionHash string) (claude.Grade, bool, error) {
+	grade, ok := c.verdicts[sessionHash]
+	return grade, ok, nil
+}
+
+func (c *fakeSessionVerdictCache) SetSessionVerdict(_ context.Context, sessionHash string, grade claude.Grade, _ time.Duration) error {
+	c.verdicts[sessionHash] = grade
+	return nil
+}
+
+func TestSessionVerdictService_ReusesFirstRequestVerdict(t *testing.T) {
+	cache := newFakeSessionVerdictCache()
+	svc := NewSessionVerdictService(cache)
+	sessionHash := "22222222-2222-2222-2222-222222222222"
+
+	_, ok, err := svc.GetSessionVerdict(context.Background(), sessionHash)
+	require.NoError(t, err)
+	require.False(t, ok, "no verdict should be cached yet")
+
+	require.NoError(t, svc.SetSessionVerdict(context.Background(), sessionHash, claude.GradeSuspicious))
+
+	grade, ok, err := svc.GetSessionVerdict(context.Background(), sessionHash)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, claude.GradeSuspicious, grade, "subsequent requests in the same session must reuse the first verdict")
+}
+
+func TestSessionVerdictService_EmptySessionHashIsNoOp(t *testing.T) {
+	cache := newFakeSessionVerdictCache()
+	svc := NewSessionVerdictService(cache)
+
+	require.NoError(t, svc.SetSessionVerdict(context.Background(), "", claude.GradeGenuine))
+	_, ok, err := svc.GetSessionVerdict(context.Background(), "")
+
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Empty(t, cache.verdicts)
+}
+
+func TestSessionVerdictService_NilCacheIsSafe(t *testing.T) {
+	svc := NewSessionVerdictService(nil)
+
+	require.NoError(t, svc.SetSessionVerdict(context.Background(), "session-1", claude.GradeGenuine))
+	_, ok, err := svc.GetSessionVerdict(context.Background(), "session-1")
+
+	require.NoError(t, err)
+	require.False(t, ok)
+}
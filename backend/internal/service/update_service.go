@@ -10,6 +10,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
+	"math/rand"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -42,25 +44,113 @@ type UpdateCache interface {
 type GitHubReleaseClient interface {
 	FetchLatestRelease(ctx context.Context, repo string) (*GitHubRelease, error)
 	DownloadFile(ctx context.Context, url, dest string, maxSize int64) error
+	// DownloadTo 将 url 的内容流式写入 w，语义（大小限制、context 取消）与 DownloadFile 一致，
+	// 但允许调用方边下载边处理（如管道进解压器），无需落地临时文件。
+	DownloadTo(ctx context.Context, url string, w io.Writer, maxSize int64) (int64, error)
 	FetchChecksumFile(ctx context.Context, url string) ([]byte, error)
+	FetchReleases(ctx context.Context, repo string, opts FetchReleasesOptions) ([]GitHubRelease, error)
+	// FetchReleaseByTag 按 tag 精确获取单个 release，tag 必须先通过校验（见 repository 层实现），
+	// 拒绝包含路径分隔符等可能篡改请求路径的输入。
+	FetchReleaseByTag(ctx context.Context, repo, tag string) (*GitHubRelease, error)
 }
 
+// RateLimitResetProvider 由能够上报最近一次观测到的 GitHub API 速率限制重置时间的
+// GitHubReleaseClient 实现可选地实现（如 repository.githubReleaseClient）。
+// UpdateService 在触达速率限制后据此计算下一次检查时间，而非盲目按固定间隔重试。
+type RateLimitResetProvider interface {
+	LastRateLimitReset() (resetAt time.Time, ok bool)
+}
+
+// CacheTTLProvider 由能够上报最近一次响应的 Cache-Control/Expires 建议缓存有效期的
+// GitHubReleaseClient 实现可选地实现（如 repository.githubReleaseClient）。UpdateService
+// 写入自身缓存时优先使用该值（经 clampCacheTTL 限幅），而不是盲目套用固定的 updateCacheTTL。
+type CacheTTLProvider interface {
+	LastCacheTTL() (ttl time.Duration, ok bool)
+}
+
+// minCacheTTL/maxCacheTTL 是 clampCacheTTL 对 GitHub 响应建议 TTL 的限幅范围：太短会导致
+// 缓存形同虚设、频繁触发 GitHub API 请求；太长则更新检测会明显滞后于真实发布节奏。
+const (
+	minCacheTTL = 30 * time.Second
+	maxCacheTTL = 1 * time.Hour
+)
+
+// clampCacheTTL 把 ttl 限制在 [minCacheTTL, maxCacheTTL] 范围内
+func clampCacheTTL(ttl time.Duration) time.Duration {
+	if ttl < minCacheTTL {
+		return minCacheTTL
+	}
+	if ttl > maxCacheTTL {
+		return maxCacheTTL
+	}
+	return ttl
+}
+
+// FetchReleasesOptions 控制 FetchReleases 的分页与过滤行为
+type FetchReleasesOptions struct {
+	IncludePrerelease bool // 是否包含预发布版本
+	MaxPages          int  // 最多跟随的分页数（Link: rel="next"），<=0 时默认为 1
+}
+
+// SelectNewestRelease 从一批 release 中按语义化版本选出最新的一个
+// 当 includePrerelease 为 false 时会跳过 Prerelease 标记的条目
+func SelectNewestRelease(releases []GitHubRelease, includePrerelease bool) *GitHubRelease {
+	var newest *GitHubRelease
+	for i := range releases {
+		r := &releases[i]
+		if !includePrerelease && r.Prerelease {
+			continue
+		}
+		if newest == nil {
+			newest = r
+			continue
+		}
+		tag := strings.TrimPrefix(r.TagName, "v")
+		newestTag := strings.TrimPrefix(newest.TagName, "v")
+		if compareVersions(newestTag, tag) < 0 {
+			newest = r
+		}
+	}
+	return newest
+}
+
+// PostDownloadHook 在下载与校验和验证成功后被调用，接收落地文件的路径，供调用方接入自定义
+// 安装流程（如停止服务、替换文件、重启）而无需修改本包。返回错误时，DownloadFileVerified
+// 会清理已下载的文件并将该错误返回给调用方。
+type PostDownloadHook func(path string) error
+
 // UpdateService handles software updates
 type UpdateService struct {
 	cache          UpdateCache
 	githubClient   GitHubReleaseClient
 	currentVersion string
 	buildType      string // "source" for manual builds, "release" for CI builds
+
+	postDownloadHook PostDownloadHook
+}
+
+// UpdateServiceOption 配置 UpdateService 的可选行为
+type UpdateServiceOption func(*UpdateService)
+
+// WithPostDownloadHook 注册下载并校验成功后执行的钩子
+func WithPostDownloadHook(hook PostDownloadHook) UpdateServiceOption {
+	return func(s *UpdateService) {
+		s.postDownloadHook = hook
+	}
 }
 
 // NewUpdateService creates a new UpdateService
-func NewUpdateService(cache UpdateCache, githubClient GitHubReleaseClient, version, buildType string) *UpdateService {
-	return &UpdateService{
+func NewUpdateService(cache UpdateCache, githubClient GitHubReleaseClient, version, buildType string, opts ...UpdateServiceOption) *UpdateService {
+	s := &UpdateService{
 		cache:          cache,
 		githubClient:   githubClient,
 		currentVersion: version,
 		buildType:      buildType,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // UpdateInfo contains update information
@@ -97,6 +187,7 @@ type GitHubRelease struct {
 	Body        string        `json:"body"`
 	PublishedAt string        `json:"published_at"`
 	HTMLURL     string        `json:"html_url"`
+	Prerelease  bool          `json:"prerelease"`
 	Assets      []GitHubAsset `json:"assets"`
 }
 
@@ -137,6 +228,84 @@ func (s *UpdateService) CheckUpdate(ctx context.Context, force bool) (*UpdateInf
 	return info, nil
 }
 
+// UpdateCheckResult 汇总一次更新检查的结果，供 NextCheckTime 决定下一次检查时机
+type UpdateCheckResult struct {
+	// RateLimited 表示本次检查因触达 GitHub API 速率限制而失败
+	RateLimited bool
+	// RateLimitReset 是速率限制的重置时间，仅当 RateLimited 为 true 且已知时有效
+	RateLimitReset time.Time
+}
+
+// nextCheckJitterMin/Max 定义 NextCheckTime 附加的抖动范围（相对基准间隔的比例），
+// 用于在多实例部署下分散检查时间点，避免同时请求 GitHub API
+const (
+	nextCheckJitterMin = 0.8
+	nextCheckJitterMax = 1.2
+)
+
+// NextCheckTime 计算更新轮询器下一次检查的时间。正常情况下为 now+interval；若上一次检查
+// 因触达 GitHub 速率限制而失败，且限速重置时间晚于 now+interval，则改用限速重置时间，
+// 避免在限速解除前反复无效重试。rng 非 nil 时对结果附加 ±20% 抖动，为 nil 时不加抖动
+// （便于测试对结果做精确比较）。
+func NextCheckTime(lastResult UpdateCheckResult, interval time.Duration, now time.Time, rng *rand.Rand) time.Time {
+	next := now.Add(interval)
+	if lastResult.RateLimited && lastResult.RateLimitReset.After(next) {
+		next = lastResult.RateLimitReset
+	}
+	if rng == nil {
+		return next
+	}
+
+	jitter := nextCheckJitterMin + rng.Float64()*(nextCheckJitterMax-nextCheckJitterMin)
+	jittered := time.Duration(float64(next.Sub(now)) * jitter)
+	return now.Add(jittered)
+}
+
+// lastCheckResult 从最近一次 CheckUpdate 的结果中推导出 NextCheckTime 所需的信号：
+// 是否触达了速率限制（CheckUpdate 会把底层的获取错误折叠进 info.Warning 而不是返回值），
+// 以及（若 githubClient 实现了 RateLimitResetProvider）已知的重置时间。
+func (s *UpdateService) lastCheckResult(info *UpdateInfo) UpdateCheckResult {
+	if info == nil || info.Warning == "" {
+		return UpdateCheckResult{}
+	}
+	rateLimited := strings.Contains(info.Warning, "403") || strings.Contains(info.Warning, "429")
+	if !rateLimited {
+		return UpdateCheckResult{}
+	}
+
+	result := UpdateCheckResult{RateLimited: true}
+	if provider, ok := s.githubClient.(RateLimitResetProvider); ok {
+		if resetAt, ok := provider.LastRateLimitReset(); ok {
+			result.RateLimitReset = resetAt
+		}
+	}
+	return result
+}
+
+// RunPeriodicCheck 在后台按 NextCheckTime 计算出的间隔循环调用 CheckUpdate，直到 ctx 被取消。
+// onResult（可为 nil）在每次检查后被调用，用于让调用方感知结果或记录日志。
+func (s *UpdateService) RunPeriodicCheck(ctx context.Context, interval time.Duration, onResult func(*UpdateInfo, error)) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			info, err := s.CheckUpdate(ctx, true)
+			if onResult != nil {
+				onResult(info, err)
+			}
+
+			next := NextCheckTime(s.lastCheckResult(info), interval, time.Now(), rng)
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
 // PerformUpdate downloads and applies the update
 // Uses atomic file replacement pattern for safe in-place updates
 func (s *UpdateService) PerformUpdate(ctx context.Context) error {
@@ -310,6 +479,52 @@ func (s *UpdateService) downloadFile(ctx context.Context, downloadURL, dest stri
 	return s.githubClient.DownloadFile(ctx, downloadURL, dest, maxDownloadSize)
 }
 
+// ChecksumPolicy controls what DownloadFileVerified does when a release
+// doesn't provide a checksums asset to verify the download against.
+type ChecksumPolicy int
+
+const (
+	// ChecksumRequire fails the download when no checksum is available (default, fail-closed).
+	ChecksumRequire ChecksumPolicy = iota
+	// ChecksumPreferButAllow verifies when a checksum is available, otherwise proceeds with a warning.
+	ChecksumPreferButAllow
+	// ChecksumSkip never verifies, even if a checksum is available.
+	ChecksumSkip
+)
+
+// DownloadFileVerified downloads a file and applies checksum verification according to policy.
+// When checksumURL is empty, the outcome depends on policy: ChecksumRequire rejects the
+// download, ChecksumPreferButAllow proceeds with a logged warning, and ChecksumSkip proceeds silently.
+func (s *UpdateService) DownloadFileVerified(ctx context.Context, downloadURL, checksumURL, dest string, policy ChecksumPolicy) error {
+	if err := s.downloadFile(ctx, downloadURL, dest); err != nil {
+		return err
+	}
+
+	if policy != ChecksumSkip {
+		if checksumURL == "" {
+			switch policy {
+			case ChecksumPreferButAllow:
+				log.Printf("[UpdateService] Warning: no checksum available for %s, proceeding unverified", downloadURL)
+			default: // ChecksumRequire
+				_ = os.Remove(dest)
+				return fmt.Errorf("checksum verification required but no checksum is available for %s", downloadURL)
+			}
+		} else if err := s.verifyChecksum(ctx, dest, checksumURL); err != nil {
+			_ = os.Remove(dest)
+			return err
+		}
+	}
+
+	if s.postDownloadHook != nil {
+		if err := s.postDownloadHook(dest); err != nil {
+			_ = os.Remove(dest)
+			return fmt.Errorf("post-download hook failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (s *UpdateService) getArchiveName() string {
 	osName := runtime.GOOS
 	arch := runtime.GOARCH
@@ -426,7 +641,9 @@ func (s *UpdateService) extractBinary(archivePath, destPath string) error {
 
 			// Only extract the specific binary we need
 			if baseName == "sub2api" || baseName == "sub2api.exe" {
-				// Additional security: limit file size (max 500MB)
+				// Additional security: limit file size (max 500MB). hdr.Size is attacker-controlled
+				// tar metadata, so this is a cheap early rejection, not a substitute for the
+				// LimitReader-enforced check below on the actual decompressed byte count.
 				const maxBinarySize = 500 * 1024 * 1024
 				if hdr.Size > maxBinarySize {
 					return fmt.Errorf("binary too large: %d bytes (max %d)", hdr.Size, maxBinarySize)
@@ -437,13 +654,7 @@ func (s *UpdateService) extractBinary(archivePath, destPath string) error {
 					return err
 				}
 
-				// Use LimitReader to prevent decompression bombs
-				limited := io.LimitReader(tr, maxBinarySize)
-				if _, err := io.Copy(out, limited); err != nil {
-					_ = out.Close()
-					return err
-				}
-				if err := out.Close(); err != nil {
+				if err := copyLimitedOrCleanup(out, tr, maxBinarySize, destPath); err != nil {
 					return err
 				}
 				return nil
@@ -459,12 +670,32 @@ func (s *UpdateService) extractBinary(archivePath, destPath string) error {
 		return err
 	}
 
-	limited := io.LimitReader(reader, maxBinarySize)
-	if _, err := io.Copy(out, limited); err != nil {
+	return copyLimitedOrCleanup(out, reader, maxBinarySize, destPath)
+}
+
+// copyLimitedOrCleanup 将 src 拷贝到 out（并负责关闭 out），最多写入 maxSize 字节。src 可能是
+// gzip.Reader 这样的解压流：压缩包声明的大小（如 tar header 里的 Size）由发送方提供、不可信，
+// 一个体积很小的压缩文件可以解压出远超声明大小的数据（decompression bomb），因此这里用
+// io.LimitReader(src, maxSize+1) 读取，若实际读到的字节数超过 maxSize 则视为超限：删除已写入
+// 的部分文件并返回错误，而不是像单纯的 io.LimitReader 那样悄悄截断在 maxSize 处、把超限伪装成
+// 提取成功。
+func copyLimitedOrCleanup(out *os.File, src io.Reader, maxSize int64, destPath string) error {
+	limited := io.LimitReader(src, maxSize+1)
+	written, err := io.Copy(out, limited)
+	if err != nil {
 		_ = out.Close()
+		_ = os.Remove(destPath)
 		return err
 	}
-	return out.Close()
+	if err := out.Close(); err != nil {
+		_ = os.Remove(destPath)
+		return err
+	}
+	if written > maxSize {
+		_ = os.Remove(destPath)
+		return fmt.Errorf("decompressed binary exceeded maximum size of %d bytes", maxSize)
+	}
+	return nil
 }
 
 func (s *UpdateService) getFromCache(ctx context.Context) (*UpdateInfo, error) {
@@ -508,7 +739,15 @@ func (s *UpdateService) saveToCache(ctx context.Context, info *UpdateInfo) {
 	}
 
 	data, _ := json.Marshal(cacheData)
-	_ = s.cache.SetUpdateInfo(ctx, string(data), time.Duration(updateCacheTTL)*time.Second)
+
+	ttl := time.Duration(updateCacheTTL) * time.Second
+	if provider, ok := s.githubClient.(CacheTTLProvider); ok {
+		if observed, observedOK := provider.LastCacheTTL(); observedOK {
+			ttl = clampCacheTTL(observed)
+		}
+	}
+
+	_ = s.cache.SetUpdateInfo(ctx, string(data), ttl)
 }
 
 // compareVersions compares two semantic versions
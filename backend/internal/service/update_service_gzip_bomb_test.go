@@ -0,0 +1,64 @@
+//go:build unit
+
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyLimitedOrCleanup_RejectsDecompressedOutputExceedingLimit(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write([]byte(strings.Repeat("a", 10_000)))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	// A highly compressible payload: tiny on the wire, large once decompressed.
+	require.Less(t, compressed.Len(), 200, "fixture should be a small compressed payload")
+
+	gzr, err := gzip.NewReader(bytes.NewReader(compressed.Bytes()))
+	require.NoError(t, err)
+	defer func() { _ = gzr.Close() }()
+
+	destPath := filepath.Join(t.TempDir(), "extracted-binary")
+	out, err := os.Create(destPath)
+	require.NoError(t, err)
+
+	err = copyLimitedOrCleanup(out, gzr, 1024, destPath)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeded maximum size")
+	_, statErr := os.Stat(destPath)
+	require.True(t, os.IsNotExist(statErr), "partial file must be cleaned up when the decompressed size exceeds the limit")
+}
+
+func TestCopyLimitedOrCleanup_AllowsPayloadWithinLimit(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	payload := []byte("small binary contents")
+	_, err := gz.Write(payload)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	gzr, err := gzip.NewReader(bytes.NewReader(compressed.Bytes()))
+	require.NoError(t, err)
+	defer func() { _ = gzr.Close() }()
+
+	destPath := filepath.Join(t.TempDir(), "extracted-binary")
+	out, err := os.Create(destPath)
+	require.NoError(t, err)
+
+	err = copyLimitedOrCleanup(out, gzr, 1024, destPath)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(destPath)
+	require.NoError(t, err)
+	require.Equal(t, payload, got)
+}
@@ -0,0 +1,241 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockGitHubReleaseClient is a minimal GitHubReleaseClient stub for unit tests.
+type mockGitHubReleaseClient struct {
+	downloadContent string
+
+	fetchLatestReleaseCalls int
+	latestRelease           *GitHubRelease
+}
+
+func (m *mockGitHubReleaseClient) FetchLatestRelease(ctx context.Context, repo string) (*GitHubRelease, error) {
+	m.fetchLatestReleaseCalls++
+	if m.latestRelease != nil {
+		return m.latestRelease, nil
+	}
+	return &GitHubRelease{TagName: "v1.0.0"}, nil
+}
+
+func (m *mockGitHubReleaseClient) DownloadFile(ctx context.Context, url, dest string, maxSize int64) error {
+	return os.WriteFile(dest, []byte(m.downloadContent), 0644)
+}
+
+func (m *mockGitHubReleaseClient) DownloadTo(ctx context.Context, url string, w io.Writer, maxSize int64) (int64, error) {
+	n, err := w.Write([]byte(m.downloadContent))
+	return int64(n), err
+}
+
+func (m *mockGitHubReleaseClient) FetchChecksumFile(ctx context.Context, url string) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *mockGitHubReleaseClient) FetchReleases(ctx context.Context, repo string, opts FetchReleasesOptions) ([]GitHubRelease, error) {
+	return nil, nil
+}
+
+func (m *mockGitHubReleaseClient) FetchReleaseByTag(ctx context.Context, repo, tag string) (*GitHubRelease, error) {
+	return &GitHubRelease{TagName: tag}, nil
+}
+
+// fakeUpdateCache is an in-memory UpdateCache stub for unit tests.
+type fakeUpdateCache struct {
+	data    string
+	ok      bool
+	lastTTL time.Duration
+}
+
+func (c *fakeUpdateCache) GetUpdateInfo(ctx context.Context) (string, error) {
+	if !c.ok {
+		return "", errors.New("not found")
+	}
+	return c.data, nil
+}
+
+func (c *fakeUpdateCache) SetUpdateInfo(ctx context.Context, data string, ttl time.Duration) error {
+	c.data = data
+	c.ok = true
+	c.lastTTL = ttl
+	return nil
+}
+
+// cacheTTLGitHubClient wraps mockGitHubReleaseClient and additionally implements
+// CacheTTLProvider, for tests that verify UpdateService honors an observed Cache-Control TTL.
+type cacheTTLGitHubClient struct {
+	mockGitHubReleaseClient
+	ttl   time.Duration
+	ttlOK bool
+}
+
+func (c *cacheTTLGitHubClient) LastCacheTTL() (time.Duration, bool) {
+	return c.ttl, c.ttlOK
+}
+
+func TestCheckUpdate_ForceRefreshBypassesFreshCache(t *testing.T) {
+	client := &mockGitHubReleaseClient{latestRelease: &GitHubRelease{TagName: "v1.0.0"}}
+	cache := &fakeUpdateCache{
+		data: `{"latest":"v1.0.0","release_info":null,"timestamp":` + strconv.FormatInt(time.Now().Unix(), 10) + `}`,
+		ok:   true,
+	}
+	svc := NewUpdateService(cache, client, "1.0.0", "release")
+
+	_, err := svc.CheckUpdate(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, 0, client.fetchLatestReleaseCalls, "cached result should be used when not forced")
+
+	_, err = svc.CheckUpdate(context.Background(), true)
+	require.NoError(t, err)
+	require.Equal(t, 1, client.fetchLatestReleaseCalls, "forced refresh should bypass the fresh cache and hit GitHub")
+}
+
+func TestNextCheckTime_NormalIntervalWithoutRateLimit(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	interval := 20 * time.Minute
+
+	got := NextCheckTime(UpdateCheckResult{}, interval, now, nil)
+
+	require.Equal(t, now.Add(interval), got)
+}
+
+func TestNextCheckTime_RateLimitedHonorsLaterResetTime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	interval := 20 * time.Minute
+	resetAt := now.Add(2 * time.Hour)
+
+	got := NextCheckTime(UpdateCheckResult{RateLimited: true, RateLimitReset: resetAt}, interval, now, nil)
+
+	require.Equal(t, resetAt, got)
+}
+
+func TestNextCheckTime_RateLimitedButResetBeforeIntervalUsesInterval(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	interval := 20 * time.Minute
+	resetAt := now.Add(1 * time.Minute)
+
+	got := NextCheckTime(UpdateCheckResult{RateLimited: true, RateLimitReset: resetAt}, interval, now, nil)
+
+	require.Equal(t, now.Add(interval), got)
+}
+
+func TestNextCheckTime_WithJitterStaysWithinBounds(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	interval := 20 * time.Minute
+	rng := rand.New(rand.NewSource(1))
+
+	got := NextCheckTime(UpdateCheckResult{}, interval, now, rng)
+
+	require.GreaterOrEqual(t, got, now.Add(time.Duration(float64(interval)*nextCheckJitterMin)))
+	require.LessOrEqual(t, got, now.Add(time.Duration(float64(interval)*nextCheckJitterMax)))
+}
+
+func TestDownloadFileVerified_ChecksumRequire_MissingChecksum_Fails(t *testing.T) {
+	client := &mockGitHubReleaseClient{downloadContent: "binary"}
+	svc := NewUpdateService(nil, client, "1.0.0", "release")
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	err := svc.DownloadFileVerified(context.Background(), "https://example.com/a", "", dest, ChecksumRequire)
+
+	require.Error(t, err)
+	_, statErr := os.Stat(dest)
+	require.Error(t, statErr, "file should be removed when checksum is required but missing")
+}
+
+func TestDownloadFileVerified_ChecksumPreferButAllow_MissingChecksum_Succeeds(t *testing.T) {
+	client := &mockGitHubReleaseClient{downloadContent: "binary"}
+	svc := NewUpdateService(nil, client, "1.0.0", "release")
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	err := svc.DownloadFileVerified(context.Background(), "https://example.com/a", "", dest, ChecksumPreferButAllow)
+
+	require.NoError(t, err)
+	_, statErr := os.Stat(dest)
+	require.NoError(t, statErr, "file should remain when policy allows unverified downloads")
+}
+
+func TestDownloadFileVerified_ChecksumSkip_MissingChecksum_Succeeds(t *testing.T) {
+	client := &mockGitHubReleaseClient{downloadContent: "binary"}
+	svc := NewUpdateService(nil, client, "1.0.0", "release")
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	err := svc.DownloadFileVerified(context.Background(), "https://example.com/a", "", dest, ChecksumSkip)
+
+	require.NoError(t, err)
+}
+
+func TestDownloadFileVerified_PostDownloadHook_SucceedsAndReceivesPath(t *testing.T) {
+	client := &mockGitHubReleaseClient{downloadContent: "binary"}
+	var gotPath string
+	hook := func(path string) error {
+		gotPath = path
+		return nil
+	}
+	svc := NewUpdateService(nil, client, "1.0.0", "release", WithPostDownloadHook(hook))
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	err := svc.DownloadFileVerified(context.Background(), "https://example.com/a", "", dest, ChecksumSkip)
+
+	require.NoError(t, err)
+	require.Equal(t, dest, gotPath)
+	_, statErr := os.Stat(dest)
+	require.NoError(t, statErr, "file should remain when hook succeeds")
+}
+
+func TestDownloadFileVerified_PostDownloadHook_ErrorCleansUpFile(t *testing.T) {
+	client := &mockGitHubReleaseClient{downloadContent: "binary"}
+	hook := func(path string) error {
+		return errors.New("install step failed")
+	}
+	svc := NewUpdateService(nil, client, "1.0.0", "release", WithPostDownloadHook(hook))
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	err := svc.DownloadFileVerified(context.Background(), "https://example.com/a", "", dest, ChecksumSkip)
+
+	require.Error(t, err)
+	_, statErr := os.Stat(dest)
+	require.Error(t, statErr, "file should be removed when the hook fails")
+}
+
+func TestCheckUpdate_HonorsCacheTTLFromGitHubResponse(t *testing.T) {
+	client := &cacheTTLGitHubClient{
+		mockGitHubReleaseClient: mockGitHubReleaseClient{latestRelease: &GitHubRelease{TagName: "v1.0.0"}},
+		ttl:                     45 * time.Second,
+		ttlOK:                   true,
+	}
+	cache := &fakeUpdateCache{}
+	svc := NewUpdateService(cache, client, "1.0.0", "release")
+
+	_, err := svc.CheckUpdate(context.Background(), true)
+	require.NoError(t, err)
+	require.Equal(t, 45*time.Second, cache.lastTTL)
+}
+
+func TestCheckUpdate_FallsBackToDefaultTTLWhenNotReported(t *testing.T) {
+	client := &mockGitHubReleaseClient{latestRelease: &GitHubRelease{TagName: "v1.0.0"}}
+	cache := &fakeUpdateCache{}
+	svc := NewUpdateService(cache, client, "1.0.0", "release")
+
+	_, err := svc.CheckUpdate(context.Background(), true)
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(updateCacheTTL)*time.Second, cache.lastTTL)
+}
+
+func TestClampCacheTTL_ClampsToConfiguredBounds(t *testing.T) {
+	require.Equal(t, minCacheTTL, clampCacheTTL(1*time.Second))
+	require.Equal(t, maxCacheTTL, clampCacheTTL(24*time.Hour))
+	require.Equal(t, 5*time.Minute, clampCacheTTL(5*time.Minute))
+}
@@ -0,0 +1,40 @@
+package requestheaders
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DefaultInternalHeaderPrefixes 是转发到上游前默认剥离的内部头部前缀（不区分大小写）。
+// 代理内部使用的关联 ID、强制指纹、调试开关等头部均以此前缀命名，绝不能泄露给上游。
+var DefaultInternalHeaderPrefixes = []string{"X-Sub2API-"}
+
+// StripInternalHeaders 从 req 中移除所有前缀匹配 prefixes（不区分大小写）的头部，
+// 用于在请求转发上游前清理仅供代理内部使用的头部。prefixes 为空时使用
+// DefaultInternalHeaderPrefixes。
+func StripInternalHeaders(req *http.Request, prefixes []string) {
+	if req == nil {
+		return
+	}
+	if len(prefixes) == 0 {
+		prefixes = DefaultInternalHeaderPrefixes
+	}
+
+	lowerPrefixes := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		lowerPrefixes[i] = strings.ToLower(prefix)
+	}
+
+	for key := range req.Header {
+		lowerKey := strings.ToLower(key)
+		for _, prefix := range lowerPrefixes {
+			if prefix == "" {
+				continue
+			}
+			if strings.HasPrefix(lowerKey, prefix) {
+				req.Header.Del(key)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package requestheaders
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStripInternalHeaders_RemovesDefaultPrefixMatches(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("X-Sub2API-Correlation-Id", "abc-123")
+	req.Header.Set("X-Sub2API-Force-Fingerprint", "1")
+	req.Header.Set("X-Stainless-Os", "Linux")
+	req.Header.Set("User-Agent", "claude-cli/1.0.0")
+
+	StripInternalHeaders(req, nil)
+
+	if req.Header.Get("X-Sub2API-Correlation-Id") != "" {
+		t.Fatalf("expected X-Sub2API-Correlation-Id removed, got %q", req.Header.Get("X-Sub2API-Correlation-Id"))
+	}
+	if req.Header.Get("X-Sub2API-Force-Fingerprint") != "" {
+		t.Fatalf("expected X-Sub2API-Force-Fingerprint removed, got %q", req.Header.Get("X-Sub2API-Force-Fingerprint"))
+	}
+	if req.Header.Get("X-Stainless-Os") != "Linux" {
+		t.Fatalf("expected X-Stainless-Os to remain, got %q", req.Header.Get("X-Stainless-Os"))
+	}
+	if req.Header.Get("User-Agent") != "claude-cli/1.0.0" {
+		t.Fatalf("expected User-Agent to remain, got %q", req.Header.Get("User-Agent"))
+	}
+}
+
+func TestStripInternalHeaders_IsCaseInsensitive(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("x-sub2api-debug", "true")
+
+	StripInternalHeaders(req, nil)
+
+	if req.Header.Get("x-sub2api-debug") != "" {
+		t.Fatalf("expected x-sub2api-debug removed, got %q", req.Header.Get("x-sub2api-debug"))
+	}
+}
+
+func TestStripInternalHeaders_CustomPrefixes(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	req.Header.Set("X-Internal-Debug", "true")
+	req.Header.Set("X-Sub2API-Correlation-Id", "abc-123")
+
+	StripInternalHeaders(req, []string{"X-Internal-"})
+
+	if req.Header.Get("X-Internal-Debug") != "" {
+		t.Fatalf("expected X-Internal-Debug removed, got %q", req.Header.Get("X-Internal-Debug"))
+	}
+	if req.Header.Get("X-Sub2API-Correlation-Id") != "abc-123" {
+		t.Fatalf("expected X-Sub2API-Correlation-Id to remain when using a custom prefix list, got %q", req.Header.Get("X-Sub2API-Correlation-Id"))
+	}
+}
+
+func TestStripInternalHeaders_NilRequestIsNoOp(t *testing.T) {
+	StripInternalHeaders(nil, nil)
+}